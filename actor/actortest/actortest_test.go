@@ -0,0 +1,86 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package actortest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+)
+
+func TestReceiveTimeoutWithVirtualClock(t *testing.T) {
+	clock := NewVirtualClock(time.Unix(0, 0))
+	system := actor.NewSystem(actor.Config{Clock: clock})
+	defer system.Shutdown()
+
+	probe := NewTestProbe(t, system)
+	pid := system.Spawn(Synchronous(actor.FromFunc(func(ctx actor.Context) {
+		switch ctx.Message().(type) {
+		case *actor.Started:
+			ctx.SetReceiveTimeout(time.Second)
+		case *actor.ReceiveTimeout:
+			probe.PID().Tell("timeout")
+		}
+	})))
+	defer pid.Stop()
+
+	clock.Advance(999 * time.Millisecond)
+	probe.ExpectNoMsg(0)
+	clock.Advance(time.Millisecond)
+	probe.ExpectMsg("timeout", 0)
+}
+
+func TestFutureTimeoutWithVirtualClock(t *testing.T) {
+	clock := NewVirtualClock(time.Unix(0, 0))
+	system := actor.NewSystem(actor.Config{Clock: clock})
+	defer system.Shutdown()
+
+	future := system.NewFuture(time.Minute)
+	clock.Advance(time.Minute)
+	if _, err := future.Result(); err != actor.ErrTimeout {
+		t.Fatalf("expected %v, got %v", actor.ErrTimeout, err)
+	}
+}
+
+func TestProbeWatch(t *testing.T) {
+	probe := NewTestProbe(t, nil)
+	defer probe.Stop()
+
+	pid := actor.Spawn(Synchronous(actor.FromFunc(func(ctx actor.Context) {})))
+	probe.Watch(pid)
+	pid.Stop()
+	msg := probe.ExpectMsgType(&actor.Terminated{}, 0).(*actor.Terminated)
+	if msg.Who.Id != pid.Id {
+		t.Fatalf("expected %v to be terminated, got %v", pid, msg.Who)
+	}
+}
@@ -0,0 +1,152 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package actortest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+)
+
+// VirtualClock is an actor.Clock whose time only moves when Advance is called.
+// Timers fire in the order of their deadline, on the goroutine calling Advance
+type VirtualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	seq    uint64
+	timers []*virtualTimer
+}
+
+// NewVirtualClock returns a VirtualClock set to start
+func NewVirtualClock(start time.Time) *VirtualClock {
+	return &VirtualClock{now: start}
+}
+
+// Now returns the current virtual time
+func (c *VirtualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// AfterFunc calls f once the clock advanced by d
+func (c *VirtualClock) AfterFunc(d time.Duration, f func()) actor.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &virtualTimer{clock: c, f: f}
+	c.schedule(t, d)
+	return t
+}
+
+// Advance moves the clock forward by d, firing the timers that become due
+func (c *VirtualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	target := c.now.Add(d)
+	for {
+		t := c.next(target)
+		if t == nil {
+			break
+		}
+		c.now = t.deadline
+		c.mu.Unlock()
+		t.f()
+		c.mu.Lock()
+	}
+	c.now = target
+	c.mu.Unlock()
+}
+
+// Pending returns the number of timers waiting to fire
+func (c *VirtualClock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// next removes and returns the earliest timer due at target, or nil if there is none
+func (c *VirtualClock) next(target time.Time) *virtualTimer {
+	i := -1
+	for j, t := range c.timers {
+		if t.deadline.After(target) {
+			continue
+		}
+		if i == -1 || t.deadline.Before(c.timers[i].deadline) || (t.deadline.Equal(c.timers[i].deadline) && t.seq < c.timers[i].seq) {
+			i = j
+		}
+	}
+	if i == -1 {
+		return nil
+	}
+	t := c.timers[i]
+	c.remove(t)
+	return t
+}
+
+func (c *VirtualClock) schedule(t *virtualTimer, d time.Duration) {
+	c.seq++
+	t.seq = c.seq
+	t.deadline = c.now.Add(d)
+	c.timers = append(c.timers, t)
+}
+
+// remove removes t from the pending timers, it returns false if t was not pending
+func (c *VirtualClock) remove(t *virtualTimer) bool {
+	for i, pending := range c.timers {
+		if pending == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type virtualTimer struct {
+	clock    *VirtualClock
+	f        func()
+	deadline time.Time
+	seq      uint64
+}
+
+func (t *virtualTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.clock.remove(t)
+}
+
+func (t *virtualTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	pending := t.clock.remove(t)
+	t.clock.schedule(t, d)
+	return pending
+}
@@ -0,0 +1,40 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+/*
+Package actortest provides utilities to test actors deterministically.
+
+A TestProbe is an actor recording the messages it receives, with ExpectMsg and ExpectNoMsg to assert them.
+Synchronous makes an actor process its messages on the goroutine sending them, and a VirtualClock,
+set on an actor system with actor.Config or actor.WithClock, moves receive timeouts, future timeouts
+and backoff delays forward only when the test advances it.
+*/
+package actortest
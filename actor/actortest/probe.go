@@ -0,0 +1,181 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package actortest
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+	"github.com/OnyxPay/OnyxChain-eventbus/mailbox"
+)
+
+// Synchronous makes the actors spawned from props process their messages on the goroutine sending them,
+// so a message told to a local actor is handled before Tell returns. It returns props
+func Synchronous(props *actor.Props) *actor.Props {
+	return props.WithDispatcher(mailbox.NewSynchronizedDispatcher(300))
+}
+
+// watch makes the probe actor watch a PID
+type watch struct {
+	pid *actor.PID
+}
+
+type delivery struct {
+	message interface{}
+	sender  *actor.PID
+}
+
+// TestProbe is an actor recording the messages it receives, except its own lifecycle messages
+type TestProbe struct {
+	t          testing.TB
+	pid        *actor.PID
+	deliveries chan delivery
+	sender     *actor.PID
+}
+
+// NewTestProbe spawns a TestProbe in system, or in the default system if system is nil
+func NewTestProbe(t testing.TB, system *actor.ActorSystem) *TestProbe {
+	if system == nil {
+		system = actor.DefaultSystem()
+	}
+	p := &TestProbe{
+		t:          t,
+		deliveries: make(chan delivery, 1024),
+	}
+	props := Synchronous(actor.FromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case *actor.Started, *actor.Stopping, *actor.Stopped, *actor.Restarting:
+			//ignore
+		case *watch:
+			ctx.Watch(msg.pid)
+		default:
+			p.deliveries <- delivery{message: ctx.Message(), sender: ctx.Sender()}
+		}
+	}))
+	p.pid = system.Spawn(props)
+	return p
+}
+
+// PID returns the PID of the probe
+func (p *TestProbe) PID() *actor.PID {
+	return p.pid
+}
+
+// Sender returns the sender of the last message received by the probe
+func (p *TestProbe) Sender() *actor.PID {
+	return p.sender
+}
+
+// Request sends message to pid with the probe as sender
+func (p *TestProbe) Request(pid *actor.PID, message interface{}) {
+	pid.Request(message, p.pid)
+}
+
+// Reply sends message to the sender of the last message received by the probe
+func (p *TestProbe) Reply(message interface{}) {
+	p.t.Helper()
+	if p.sender == nil {
+		p.t.Fatalf("no sender to reply %v to", message)
+	}
+	p.sender.Request(message, p.pid)
+}
+
+// Watch makes the probe receive the Terminated message of pid
+func (p *TestProbe) Watch(pid *actor.PID) {
+	p.pid.Tell(&watch{pid: pid})
+}
+
+// Receive returns the next message received by the probe, or false if none was received before the timeout
+func (p *TestProbe) Receive(timeout time.Duration) (interface{}, bool) {
+	select {
+	case d := <-p.deliveries:
+		p.sender = d.sender
+		return d.message, true
+	default:
+	}
+	if timeout <= 0 {
+		return nil, false
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case d := <-p.deliveries:
+		p.sender = d.sender
+		return d.message, true
+	case <-timer.C:
+		return nil, false
+	}
+}
+
+// ExpectMsg fails the test unless the next message received within the timeout equals expected, it returns the message
+func (p *TestProbe) ExpectMsg(expected interface{}, timeout time.Duration) interface{} {
+	p.t.Helper()
+	msg, ok := p.Receive(timeout)
+	if !ok {
+		p.t.Fatalf("expected %#v within %v, received nothing", expected, timeout)
+	}
+	if !reflect.DeepEqual(msg, expected) {
+		p.t.Fatalf("expected %#v, received %#v", expected, msg)
+	}
+	return msg
+}
+
+// ExpectMsgType fails the test unless the next message received within the timeout has the type of sample,
+// it returns the message
+func (p *TestProbe) ExpectMsgType(sample interface{}, timeout time.Duration) interface{} {
+	p.t.Helper()
+	msg, ok := p.Receive(timeout)
+	if !ok {
+		p.t.Fatalf("expected a %T within %v, received nothing", sample, timeout)
+	}
+	if reflect.TypeOf(msg) != reflect.TypeOf(sample) {
+		p.t.Fatalf("expected a %T, received %#v", sample, msg)
+	}
+	return msg
+}
+
+// ExpectNoMsg fails the test if the probe receives a message within the timeout.
+// A zero timeout only checks the messages already received, which suits synchronous actors
+func (p *TestProbe) ExpectNoMsg(timeout time.Duration) {
+	p.t.Helper()
+	if msg, ok := p.Receive(timeout); ok {
+		p.t.Fatalf("expected no message within %v, received %#v", timeout, msg)
+	}
+}
+
+// Stop stops the probe
+func (p *TestProbe) Stop() {
+	p.pid.Stop()
+}
@@ -29,53 +29,29 @@ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
 See the License for the specific language governing permissions and
 limitations under the License.
 *****************************************************/
+package actor
 
-package zmqremote
+import "time"
 
-import "github.com/OnyxPay/OnyxChain-eventbus/actor"
-
-type EndpointTerminatedEvent struct {
-	Address string
-}
-
-type EndpointConnectedEvent struct {
-	Address string
-}
-
-type remoteWatch struct {
-	Watcher *actor.PID
-	Watchee *actor.PID
+// Clock is the time source of an actor system. It drives receive timeouts, future timeouts,
+// restart statistics and backoff delays, so tests can replace it with a virtual clock
+type Clock interface {
+	Now() time.Time
+	AfterFunc(d time.Duration, f func()) Timer
 }
 
-type remoteUnwatch struct {
-	Watcher *actor.PID
-	Watchee *actor.PID
+// Timer calls its function once its duration elapsed on the Clock that created it
+type Timer interface {
+	Stop() bool
+	Reset(d time.Duration) bool
 }
 
-type remoteDeliver struct {
-	header       actor.ReadonlyMessageHeader
-	message      interface{}
-	target       *actor.PID
-	sender       *actor.PID
-	serializerID int32
-}
+type realClock struct{}
 
-type remoteTerminate struct {
-	Watcher *actor.PID
-	Watchee *actor.PID
+func (realClock) Now() time.Time {
+	return time.Now()
 }
 
-type JsonMessage struct {
-	TypeName string
-	Json     string
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
 }
-
-var (
-	stopMessage interface{} = &actor.Stop{}
-)
-
-var (
-	ActorPidRespErr         interface{} = &ActorPidResponse{StatusCode: ResponseStatusCodeERROR.ToInt32()}
-	ActorPidRespTimeout     interface{} = &ActorPidResponse{StatusCode: ResponseStatusCodeTIMEOUT.ToInt32()}
-	ActorPidRespUnavailable interface{} = &ActorPidResponse{StatusCode: ResponseStatusCodeUNAVAILABLE.ToInt32()}
-)
@@ -36,31 +36,38 @@ import (
 	"github.com/OnyxPay/OnyxChain-eventbus/log"
 )
 
-type deadLetterProcess struct{}
+type deadLetterProcess struct {
+	system *ActorSystem
+}
 
 var (
-	deadLetter           Process = &deadLetterProcess{}
+	deadLetter           Process = defaultSystem.deadLetter
 	deadLetterSubscriber *eventstream.Subscription
+	deadLetterFn         func(evt interface{})
+	deadWatchSubscriber  *eventstream.Subscription
 )
 
 func init() {
-	deadLetterSubscriber = eventstream.Subscribe(func(msg interface{}) {
-		if deadLetter, ok := msg.(*DeadLetterEvent); ok {
-			plog.Debug("[DeadLetter]", log.Stringer("pid", deadLetter.PID), log.Message(deadLetter.Message), log.Stringer("sender", deadLetter.Sender))
-		}
-	})
+	deadLetterFn = logDeadLetter
+	deadLetterSubscriber = defaultSystem.eventStream.SubscribeType((*DeadLetterEvent)(nil), deadLetterFn)
 
 	//this subscriber may not be deactivated.
 	//it ensures that Watch commands that reach a stopped actor gets a Terminated message back.
 	//This can happen if one actor tries to Watch a PID, while another thread sends a Stop message.
-	eventstream.Subscribe(func(msg interface{}) {
-		if deadLetter, ok := msg.(*DeadLetterEvent); ok {
-			if m, ok := deadLetter.Message.(*Watch); ok {
-				//we know that this is a local actor since we get it on our own event stream, thus the address is not terminated
-				m.Watcher.sendSystemMessage(&Terminated{AddressTerminated: false, Who: deadLetter.PID})
-			}
-		}
-	})
+	deadWatchSubscriber = defaultSystem.eventStream.SubscribeType((*DeadLetterEvent)(nil), terminateDeadWatch)
+}
+
+func logDeadLetter(msg interface{}) {
+	deadLetter := msg.(*DeadLetterEvent)
+	plog.Debug("[DeadLetter]", log.Stringer("pid", deadLetter.PID), log.Message(deadLetter.Message), log.Stringer("sender", deadLetter.Sender))
+}
+
+func terminateDeadWatch(msg interface{}) {
+	deadLetter := msg.(*DeadLetterEvent)
+	if m, ok := deadLetter.Message.(*Watch); ok {
+		//we know that this is a local actor since we get it on our own event stream, thus the address is not terminated
+		m.Watcher.sendSystemMessage(&Terminated{AddressTerminated: false, Who: deadLetter.PID})
+	}
 }
 
 // A DeadLetterEvent is published via event.Publish when a message is sent to a nonexistent PID
@@ -70,17 +77,17 @@ type DeadLetterEvent struct {
 	Sender  *PID        // the process that sent the Message
 }
 
-func (*deadLetterProcess) SendUserMessage(pid *PID, message interface{}) {
+func (ref *deadLetterProcess) SendUserMessage(pid *PID, message interface{}) {
 	_, msg, sender := UnwrapEnvelope(message)
-	eventstream.Publish(&DeadLetterEvent{
+	ref.system.eventStream.Publish(&DeadLetterEvent{
 		PID:     pid,
 		Message: msg,
 		Sender:  sender,
 	})
 }
 
-func (*deadLetterProcess) SendSystemMessage(pid *PID, message interface{}) {
-	eventstream.Publish(&DeadLetterEvent{
+func (ref *deadLetterProcess) SendSystemMessage(pid *PID, message interface{}) {
+	ref.system.eventStream.Publish(&DeadLetterEvent{
 		PID:     pid,
 		Message: message,
 	})
@@ -35,8 +35,6 @@ import (
 	"errors"
 	"sync"
 	"time"
-
-	"github.com/OnyxPay/OnyxChain-eventbus/log"
 )
 
 // ErrTimeout is the error used when a future times out before receiving a result.
@@ -44,23 +42,7 @@ var ErrTimeout = errors.New("future: timeout")
 
 // NewFuture creates and returns a new actor.Future with a timeout of duration d
 func NewFuture(d time.Duration) *Future {
-	ref := &futureProcess{Future{cond: sync.NewCond(&sync.Mutex{})}}
-	id := ProcessRegistry.NextId()
-
-	pid, ok := ProcessRegistry.Add(ref, "future"+id)
-	if !ok {
-		plog.Error("failed to register future process", log.Stringer("pid", pid))
-	}
-
-	ref.pid = pid
-	if d >= 0 {
-		ref.t = time.AfterFunc(d, func() {
-			ref.err = ErrTimeout
-			ref.Stop(pid)
-		})
-	}
-
-	return &ref.Future
+	return defaultSystem.NewFuture(d)
 }
 
 type Future struct {
@@ -70,7 +52,7 @@ type Future struct {
 	done        bool
 	result      interface{}
 	err         error
-	t           *time.Timer
+	t           Timer
 	pipes       []*PID
 	completions []func(res interface{}, err error)
 }
@@ -164,7 +146,7 @@ func (ref *futureProcess) Stop(pid *PID) {
 	if ref.t != nil {
 		ref.t.Stop()
 	}
-	ProcessRegistry.Remove(pid)
+	pid.System().ProcessRegistry.Remove(pid)
 
 	ref.sendToPipes()
 	ref.runCompletions()
@@ -41,9 +41,10 @@ import (
 type guardiansValue struct {
 	sync.RWMutex
 	guardians map[SupervisorStrategy]*guardianProcess
+	registry  *ProcessRegistryValue
 }
 
-var guardians = &guardiansValue{guardians: make(map[SupervisorStrategy]*guardianProcess)}
+var guardians = &guardiansValue{guardians: make(map[SupervisorStrategy]*guardianProcess), registry: ProcessRegistry}
 
 func (gs *guardiansValue) getGuardianPid(s SupervisorStrategy) *PID {
 	gs.Lock()
@@ -60,9 +61,9 @@ func (gs *guardiansValue) getGuardianPid(s SupervisorStrategy) *PID {
 // newGuardian creates and returns a new actor.guardianProcess with a timeout of duration d
 func (gs *guardiansValue) newGuardian(s SupervisorStrategy) *guardianProcess {
 	ref := &guardianProcess{strategy: s}
-	id := ProcessRegistry.NextId()
+	id := gs.registry.NextId()
 
-	pid, ok := ProcessRegistry.Add(ref, "guardian"+id)
+	pid, ok := gs.registry.Add(ref, "guardian"+id)
 	if !ok {
 		plog.Error("failed to register guardian process", log.Stringer("pid", pid))
 	}
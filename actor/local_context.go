@@ -57,7 +57,7 @@ type localContext struct {
 	stopping           bool
 	restarting         bool
 	receiveTimeout     time.Duration
-	t                  *time.Timer
+	t                  Timer
 	restartStats       *RestartStatistics
 }
 
@@ -199,7 +199,7 @@ func (ctx *localContext) SetReceiveTimeout(d time.Duration) {
 	ctx.receiveTimeout = d
 	if d > 0 {
 		if ctx.t == nil {
-			ctx.t = time.AfterFunc(d, ctx.receiveTimeoutHandler)
+			ctx.t = ctx.self.System().clock.AfterFunc(d, ctx.receiveTimeoutHandler)
 		} else {
 			ctx.t.Reset(d)
 		}
@@ -235,6 +235,7 @@ func (ctx *localContext) RestartStats() *RestartStatistics {
 	//further mutations are handled within "restart"
 	if ctx.restartStats == nil {
 		ctx.restartStats = NewRestartStatistics()
+		ctx.restartStats.clock = ctx.self.System().clock
 	}
 	return ctx.restartStats
 }
@@ -395,7 +396,7 @@ func (ctx *localContext) restart() {
 }
 
 func (ctx *localContext) stopped() {
-	ProcessRegistry.Remove(ctx.self)
+	ctx.self.System().ProcessRegistry.Remove(ctx.self)
 	ctx.InvokeUserMessage(stoppedMessage)
 	otherStopped := &Terminated{Who: ctx.self}
 	ctx.watchers.ForEach(func(i int, pid PID) {
@@ -436,7 +437,7 @@ func (ctx *localContext) Unwatch(who *PID) {
 func (ctx *localContext) Respond(response interface{}) {
 	// If the message is addressed to nil forward it to the dead letter channel
 	if ctx.Sender() == nil {
-		deadLetter.SendUserMessage(nil, response)
+		ctx.self.System().deadLetter.SendUserMessage(nil, response)
 		return
 	}
 
@@ -444,12 +445,12 @@ func (ctx *localContext) Respond(response interface{}) {
 }
 
 func (ctx *localContext) Spawn(props *Props) *PID {
-	pid, _ := ctx.SpawnNamed(props, ProcessRegistry.NextId())
+	pid, _ := ctx.SpawnNamed(props, ctx.self.System().ProcessRegistry.NextId())
 	return pid
 }
 
 func (ctx *localContext) SpawnPrefix(props *Props, prefix string) *PID {
-	pid, _ := ctx.SpawnNamed(props, prefix+ProcessRegistry.NextId())
+	pid, _ := ctx.SpawnNamed(props, prefix+ctx.self.System().ProcessRegistry.NextId())
 	return pid
 }
 
@@ -458,7 +459,7 @@ func (ctx *localContext) SpawnNamed(props *Props, name string) (*PID, error) {
 		panic(errors.New("Props used to spawn child cannot have GuardianStrategy"))
 	}
 
-	pid, err := props.spawn(ctx.self.Id+"/"+name, ctx.self)
+	pid, err := props.spawn(ctx.self.System(), ctx.self.Id+"/"+name, ctx.self)
 	if err != nil {
 		return pid, err
 	}
@@ -44,13 +44,11 @@ func WithDeadLetterSubscriber(fn func(evt interface{})) optionFn {
 	return func() {
 		if deadLetterSubscriber != nil {
 			eventstream.Unsubscribe(deadLetterSubscriber)
+			deadLetterSubscriber = nil
 		}
+		deadLetterFn = fn
 		if fn != nil {
-			deadLetterSubscriber = eventstream.Subscribe(fn).
-				WithPredicate(func(m interface{}) bool {
-					_, ok := m.(*DeadLetterEvent)
-					return ok
-				})
+			deadLetterSubscriber = defaultSystem.eventStream.SubscribeType((*DeadLetterEvent)(nil), fn)
 		}
 	}
 }
@@ -64,17 +62,61 @@ func WithSupervisorSubscriber(fn func(evt interface{})) optionFn {
 	return func() {
 		if supervisionSubscriber != nil {
 			eventstream.Unsubscribe(supervisionSubscriber)
+			supervisionSubscriber = nil
 		}
+		supervisionFn = fn
 		if fn != nil {
-			supervisionSubscriber = eventstream.Subscribe(fn).
-				WithPredicate(func(m interface{}) bool {
-					_, ok := m.(*SupervisorEvent)
-					return ok
-				})
+			supervisionSubscriber = defaultSystem.eventStream.SubscribeType((*SupervisorEvent)(nil), fn)
 		}
 	}
 }
 
+// WithEventStream option makes the default actor system publish its events, such as DeadLetterEvent and SupervisorEvent,
+// on stream instead of the default EventStream. The dead letter and supervisor subscribers move along.
+//
+// Specifying nil will restore the default EventStream.
+func WithEventStream(stream *eventstream.EventStream) optionFn {
+	return func() {
+		if stream == nil {
+			stream = eventstream.Default()
+		}
+		if stream == defaultSystem.eventStream {
+			return
+		}
+		defaultSystem.eventStream = stream
+
+		deadLetterSubscriber = resubscribe(deadLetterSubscriber, (*DeadLetterEvent)(nil), deadLetterFn)
+		deadWatchSubscriber = resubscribe(deadWatchSubscriber, (*DeadLetterEvent)(nil), terminateDeadWatch)
+		supervisionSubscriber = resubscribe(supervisionSubscriber, (*SupervisorEvent)(nil), supervisionFn)
+	}
+}
+
+// WithClock option replaces the time source of the default actor system, such as with a virtual clock in tests.
+//
+// Specifying nil will restore the wall clock.
+func WithClock(clock Clock) optionFn {
+	return func() {
+		if clock == nil {
+			clock = realClock{}
+		}
+		defaultSystem.clock = clock
+	}
+}
+
+// EventStream returns the EventStream the default actor system publishes its events on
+func EventStream() *eventstream.EventStream {
+	return defaultSystem.eventStream
+}
+
+// resubscribe moves sub, subscribed to the events of typ with fn, to the event stream of the default system
+func resubscribe(sub *eventstream.Subscription, typ interface{}, fn func(evt interface{})) *eventstream.Subscription {
+	if sub == nil {
+		return nil
+	}
+	eventstream.Unsubscribe(sub)
+	return defaultSystem.eventStream.SubscribeType(typ, fn)
+}
+
 // SetOptions is used to configure the actor system
 func SetOptions(opts ...optionFn) {
 	for _, opt := range opts {
@@ -42,6 +42,8 @@ type PID struct {
 	Address string
 	Id      string
 	p       *Process
+	// system resolving the PID, nil when it was not created by a system
+	system *ActorSystem
 }
 
 /*
@@ -60,7 +62,7 @@ func (pid *PID) ref() Process {
 		}
 	}
 
-	ref, exists := ProcessRegistry.Get(pid)
+	ref, exists := pid.System().ProcessRegistry.Get(pid)
 	if exists {
 		atomic.StorePointer((*unsafe.Pointer)(unsafe.Pointer(&pid.p)), unsafe.Pointer(&ref))
 	}
@@ -86,7 +88,7 @@ func (pid *PID) Request(message interface{}, respondTo *PID) {
 
 // RequestFuture sends a message to a given PID and returns a Future
 func (pid *PID) RequestFuture(message interface{}, timeout time.Duration) *Future {
-	future := NewFuture(timeout)
+	future := pid.System().NewFuture(timeout)
 	env := &MessageEnvelope{
 		Message: message,
 		Header:  nil,
@@ -101,7 +103,7 @@ func (pid *PID) sendSystemMessage(message interface{}) {
 }
 
 func (pid *PID) StopFuture() *Future {
-	future := NewFuture(10 * time.Second)
+	future := pid.System().NewFuture(10 * time.Second)
 
 	pid.sendSystemMessage(&Watch{Watcher: future.pid})
 	pid.Stop()
@@ -118,6 +120,14 @@ func (pid *PID) Stop() {
 	pid.ref().Stop(pid)
 }
 
+// System returns the actor system resolving the PID, which is the system that created it or else the default system
+func (pid *PID) System() *ActorSystem {
+	if pid.system != nil {
+		return pid.system
+	}
+	return defaultSystem
+}
+
 func pidFromKey(key string, p *PID) {
 	i := strings.IndexByte(key, '#')
 	if i == -1 {
@@ -153,8 +163,5 @@ func NewPID(address, id string) *PID {
 
 //NewLocalPID returns a new instance of the PID struct with the address preset
 func NewLocalPID(id string) *PID {
-	return &PID{
-		Address: ProcessRegistry.Address,
-		Id:      id,
-	}
+	return defaultSystem.NewLocalPID(id)
 }
@@ -42,6 +42,10 @@ type ProcessRegistryValue struct {
 	LocalPIDs      cmap.ConcurrentMap
 	RemoteHandlers []AddressResolver
 	SequenceID     uint64
+	// system owning the registry, nil for the default system
+	system *ActorSystem
+	// local is the address of the local PIDs before remoting set Address
+	local string
 }
 
 var (
@@ -54,6 +58,7 @@ var (
 var ProcessRegistry = &ProcessRegistryValue{
 	Address:   localAddress,
 	LocalPIDs: cmap.New(),
+	local:     localAddress,
 }
 
 // An AddressResolver is used to resolve remote actors
@@ -94,6 +99,7 @@ func (pr *ProcessRegistryValue) Add(process Process, id string) (*PID, bool) {
 	return &PID{
 		Address: pr.Address,
 		Id:      id,
+		system:  pr.system,
 	}, pr.LocalPIDs.SetIfAbsent(id, process)
 }
 
@@ -106,20 +112,20 @@ func (pr *ProcessRegistryValue) Remove(pid *PID) {
 
 func (pr *ProcessRegistryValue) Get(pid *PID) (Process, bool) {
 	if pid == nil {
-		return deadLetter, false
+		return pr.deadLetter(), false
 	}
-	if pid.Address != localAddress && pid.Address != pr.Address {
+	if pid.Address != localAddress && pid.Address != pr.local && pid.Address != pr.Address {
 		for _, handler := range pr.RemoteHandlers {
 			ref, ok := handler(pid)
 			if ok {
 				return ref, true
 			}
 		}
-		return deadLetter, false
+		return pr.deadLetter(), false
 	}
 	ref, ok := pr.LocalPIDs.Get(pid.Id)
 	if !ok {
-		return deadLetter, false
+		return pr.deadLetter(), false
 	}
 	return ref.(Process), true
 }
@@ -127,7 +133,14 @@ func (pr *ProcessRegistryValue) Get(pid *PID) (Process, bool) {
 func (pr *ProcessRegistryValue) GetLocal(id string) (Process, bool) {
 	ref, ok := pr.LocalPIDs.Get(id)
 	if !ok {
-		return deadLetter, false
+		return pr.deadLetter(), false
 	}
 	return ref.(Process), true
 }
+
+func (pr *ProcessRegistryValue) deadLetter() Process {
+	if pr.system != nil {
+		return pr.system.deadLetter
+	}
+	return deadLetter
+}
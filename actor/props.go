@@ -46,6 +46,7 @@ type Props struct {
 	outboundMiddleware  []OutboundMiddleware
 	dispatcher          mailbox.Dispatcher
 	spawner             SpawnFunc
+	system              *ActorSystem
 }
 
 func (props *Props) getDispatcher() mailbox.Dispatcher {
@@ -69,7 +70,12 @@ func (props *Props) produceMailbox(invoker mailbox.MessageInvoker, dispatcher ma
 	return props.mailboxProducer(invoker, dispatcher)
 }
 
-func (props *Props) spawn(id string, parent *PID) (*PID, error) {
+func (props *Props) spawn(system *ActorSystem, id string, parent *PID) (*PID, error) {
+	if props.System() != system {
+		p := *props
+		p.system = system
+		props = &p
+	}
 	if props.spawner != nil {
 		return props.spawner(id, props, parent)
 	}
@@ -117,6 +123,20 @@ func (props *Props) WithSpawnFunc(spawn SpawnFunc) *Props {
 	return props
 }
 
+// WithSystem assigns the actor system the props spawn in, the spawn functions of ActorSystem set it for a SpawnFunc
+func (props *Props) WithSystem(system *ActorSystem) *Props {
+	props.system = system
+	return props
+}
+
+// System returns the actor system the props spawn in
+func (props *Props) System() *ActorSystem {
+	if props.system == nil {
+		return defaultSystem
+	}
+	return props.system
+}
+
 //WithFunc assigns a receive func to the props
 func (props *Props) WithFunc(f ActorFunc) *Props {
 	props.actorProducer = func() Actor { return f }
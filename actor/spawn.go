@@ -45,39 +45,42 @@ var DefaultSpawner SpawnFunc = spawn
 
 // Spawn starts a new actor based on props and named with a unique id
 func Spawn(props *Props) *PID {
-	pid, _ := SpawnNamed(props, ProcessRegistry.NextId())
-	return pid
+	return defaultSystem.Spawn(props)
 }
 
 // SpawnPrefix starts a new actor based on props and named using a prefix followed by a unique id
 func SpawnPrefix(props *Props, prefix string) (*PID, error) {
-	return SpawnNamed(props, prefix+ProcessRegistry.NextId())
+	return defaultSystem.SpawnPrefix(props, prefix)
 }
 
 // SpawnNamed starts a new actor based on props and named using the specified name
 //
 // If name exists, error will be ErrNameExists
 func SpawnNamed(props *Props, name string) (*PID, error) {
-	var parent *PID
-	if props.guardianStrategy != nil {
-		parent = guardians.getGuardianPid(props.guardianStrategy)
-	}
-	return props.spawn(name, parent)
+	return defaultSystem.SpawnNamed(props, name)
 }
 
 func spawn(id string, props *Props, parent *PID) (*PID, error) {
-	lp := &localProcess{}
-	pid, absent := ProcessRegistry.Add(lp, id)
-	if !absent {
-		return pid, ErrNameExists
+	registry := props.System().ProcessRegistry
+	pid := &PID{
+		Address: registry.Address,
+		Id:      id,
+		system:  registry.system,
 	}
 
+	// the process is set up before it is registered, messages may reach it as soon as it is
+	lp := &localProcess{}
 	cell := newLocalContext(props.actorProducer, props.getSupervisor(), props.inboundMiddleware, props.outboundMiddleware, parent)
 	mb := props.produceMailbox(cell, props.getDispatcher())
 	lp.mailbox = mb
 	var ref Process = lp
 	pid.p = &ref
 	cell.self = pid
+
+	if _, absent := registry.Add(lp, id); !absent {
+		pid.p = nil
+		return pid, ErrNameExists
+	}
 	mb.Start()
 	mb.PostSystemMessage(startedMessage)
 
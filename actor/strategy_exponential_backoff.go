@@ -58,7 +58,7 @@ func (strategy *exponentialBackoffStrategy) HandleFailure(supervisor Supervisor,
 	backoff := rs.FailureCount() * int(strategy.initialBackoff.Nanoseconds())
 	noise := rand.Intn(500)
 	dur := time.Duration(backoff + noise)
-	time.AfterFunc(dur, func() {
+	child.System().clock.AfterFunc(dur, func() {
 		supervisor.RestartChildren(child)
 	})
 }
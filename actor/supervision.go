@@ -31,8 +31,6 @@ limitations under the License.
 *****************************************************/
 package actor
 
-import "github.com/OnyxPay/OnyxChain-eventbus/eventstream"
-
 // DeciderFunc is a function which is called by a SupervisorStrategy
 type DeciderFunc func(reason interface{}) Directive
 
@@ -51,7 +49,7 @@ type Supervisor interface {
 }
 
 func logFailure(child *PID, reason interface{}, directive Directive) {
-	eventstream.Publish(&SupervisorEvent{
+	child.System().eventStream.Publish(&SupervisorEvent{
 		Child:     child,
 		Reason:    reason,
 		Directive: directive,
@@ -45,12 +45,15 @@ type SupervisorEvent struct {
 
 var (
 	supervisionSubscriber *eventstream.Subscription
+	supervisionFn         func(evt interface{})
 )
 
 func init() {
-	supervisionSubscriber = eventstream.Subscribe(func(evt interface{}) {
-		if supervisorEvent, ok := evt.(*SupervisorEvent); ok {
-			plog.Debug("[SUPERVISION]", log.Stringer("actor", supervisorEvent.Child), log.Stringer("directive", supervisorEvent.Directive), log.Object("reason", supervisorEvent.Reason))
-		}
-	})
+	supervisionFn = logSupervision
+	supervisionSubscriber = defaultSystem.eventStream.SubscribeType((*SupervisorEvent)(nil), supervisionFn)
+}
+
+func logSupervision(evt interface{}) {
+	supervisorEvent := evt.(*SupervisorEvent)
+	plog.Debug("[SUPERVISION]", log.Stringer("actor", supervisorEvent.Child), log.Stringer("directive", supervisorEvent.Directive), log.Object("reason", supervisorEvent.Reason))
 }
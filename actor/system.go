@@ -0,0 +1,220 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package actor
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/eventstream"
+	"github.com/OnyxPay/OnyxChain-eventbus/log"
+	cmap "github.com/orcaman/concurrent-map"
+)
+
+// Config configures an ActorSystem created with NewSystem
+type Config struct {
+	// Address is the address of the local PIDs until remoting sets it, it defaults to an address unique in the process
+	Address string
+	// EventStream receives the events of the system, such as DeadLetterEvent and SupervisorEvent.
+	// It defaults to a new EventStream
+	EventStream *eventstream.EventStream
+	// Clock is the time source of the system, it defaults to the wall clock
+	Clock Clock
+}
+
+// ActorSystem owns the processes of a node, with their registry, guardians and event stream.
+//
+// The package level functions, like Spawn and NewLocalPID, use the default system. Additional systems
+// allow running several independent nodes in one process
+type ActorSystem struct {
+	ProcessRegistry *ProcessRegistryValue
+	guardians       *guardiansValue
+	deadLetter      Process
+	eventStream     *eventstream.EventStream
+	clock           Clock
+	subscriptions   []*eventstream.Subscription
+}
+
+var (
+	defaultSystem  = newDefaultSystem()
+	systemSequence uint64
+)
+
+func newDefaultSystem() *ActorSystem {
+	system := &ActorSystem{
+		ProcessRegistry: ProcessRegistry,
+		guardians:       guardians,
+		eventStream:     eventstream.Default(),
+		clock:           realClock{},
+	}
+	system.deadLetter = &deadLetterProcess{system: system}
+	return system
+}
+
+// DefaultSystem returns the system used by the package level functions
+func DefaultSystem() *ActorSystem {
+	return defaultSystem
+}
+
+// NewSystem creates an actor system independent of the default one and of the other systems
+func NewSystem(config Config) *ActorSystem {
+	if config.Address == "" {
+		config.Address = localAddress + "-" + strconv.FormatUint(atomic.AddUint64(&systemSequence, 1), 10)
+	}
+	if config.EventStream == nil {
+		config.EventStream = eventstream.New()
+	}
+	if config.Clock == nil {
+		config.Clock = realClock{}
+	}
+
+	registry := &ProcessRegistryValue{
+		Address:   config.Address,
+		LocalPIDs: cmap.New(),
+		local:     config.Address,
+	}
+	system := &ActorSystem{
+		ProcessRegistry: registry,
+		guardians:       &guardiansValue{guardians: make(map[SupervisorStrategy]*guardianProcess), registry: registry},
+		eventStream:     config.EventStream,
+		clock:           config.Clock,
+	}
+	system.deadLetter = &deadLetterProcess{system: system}
+	registry.system = system
+
+	system.subscriptions = []*eventstream.Subscription{
+		system.eventStream.SubscribeType((*DeadLetterEvent)(nil), logDeadLetter),
+		system.eventStream.SubscribeType((*DeadLetterEvent)(nil), terminateDeadWatch),
+		system.eventStream.SubscribeType((*SupervisorEvent)(nil), logSupervision),
+	}
+	return system
+}
+
+// EventStream returns the EventStream the system publishes its events on
+func (system *ActorSystem) EventStream() *eventstream.EventStream {
+	return system.eventStream
+}
+
+// Clock returns the time source of the system
+func (system *ActorSystem) Clock() Clock {
+	return system.clock
+}
+
+// SetAddress sets the address of the PIDs the system creates, such as the address its remoting listens on.
+// It must be set before the actors of the system use it
+func (system *ActorSystem) SetAddress(address string) {
+	system.ProcessRegistry.Address = address
+}
+
+// Spawn starts a new actor of the system based on props and named with a unique id
+func (system *ActorSystem) Spawn(props *Props) *PID {
+	pid, _ := system.SpawnNamed(props, system.ProcessRegistry.NextId())
+	return pid
+}
+
+// SpawnPrefix starts a new actor of the system based on props and named using a prefix followed by a unique id
+func (system *ActorSystem) SpawnPrefix(props *Props, prefix string) (*PID, error) {
+	return system.SpawnNamed(props, prefix+system.ProcessRegistry.NextId())
+}
+
+// SpawnNamed starts a new actor of the system based on props and named using the specified name
+//
+// If name exists, error will be ErrNameExists
+func (system *ActorSystem) SpawnNamed(props *Props, name string) (*PID, error) {
+	var parent *PID
+	if props.guardianStrategy != nil {
+		parent = system.guardians.getGuardianPid(props.guardianStrategy)
+	}
+	return props.spawn(system, name, parent)
+}
+
+// NewPID returns a PID resolved by the system, messages to other addresses go through the remoting of the system
+func (system *ActorSystem) NewPID(address, id string) *PID {
+	return &PID{
+		Address: address,
+		Id:      id,
+		system:  system,
+	}
+}
+
+// Bind makes the system resolve a PID created without one, such as a PID decoded from a message received by
+// the remoting of the system. A PID already resolved by a system is left unchanged
+func (system *ActorSystem) Bind(pid *PID) {
+	if pid != nil && pid.system == nil {
+		pid.system = system
+	}
+}
+
+// NewLocalPID returns a PID of a local process of the system
+func (system *ActorSystem) NewLocalPID(id string) *PID {
+	return system.NewPID(system.ProcessRegistry.Address, id)
+}
+
+// NewFuture creates and returns a new actor.Future of the system with a timeout of duration d
+func (system *ActorSystem) NewFuture(d time.Duration) *Future {
+	ref := &futureProcess{Future{cond: sync.NewCond(&sync.Mutex{})}}
+	id := system.ProcessRegistry.NextId()
+
+	pid, ok := system.ProcessRegistry.Add(ref, "future"+id)
+	if !ok {
+		plog.Error("failed to register future process", log.Stringer("pid", pid))
+	}
+
+	ref.pid = pid
+	if d >= 0 {
+		ref.t = system.clock.AfterFunc(d, func() {
+			ref.err = ErrTimeout
+			ref.Stop(pid)
+		})
+	}
+
+	return &ref.Future
+}
+
+// Shutdown stops the actors of the system, the default system cannot be shut down
+func (system *ActorSystem) Shutdown() {
+	if system == defaultSystem {
+		return
+	}
+
+	for item := range system.ProcessRegistry.LocalPIDs.IterBuffered() {
+		if lp, ok := item.Val.(*localProcess); ok {
+			lp.Stop(system.NewLocalPID(item.Key))
+		}
+	}
+	for _, sub := range system.subscriptions {
+		eventstream.Unsubscribe(sub)
+	}
+	system.subscriptions = nil
+}
@@ -29,60 +29,49 @@ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
 See the License for the specific language governing permissions and
 limitations under the License.
 *****************************************************/
-package zmqremote
+package actor
 
 import (
-	"github.com/OnyxPay/OnyxChain-eventbus/actor"
-)
-
-type process struct {
-	pid *actor.PID
-}
-
-func newProcess(pid *actor.PID) actor.Process {
-	return &process{
-		pid: pid,
-	}
-}
-
-func (ref *process) SendUserMessage(pid *actor.PID, message interface{}) {
-	header, msg, sender := actor.UnwrapEnvelope(message)
-	SendMessage(pid, header, msg, sender, -1)
-}
+	"testing"
+	"time"
 
-func SendMessage(pid *actor.PID, header actor.ReadonlyMessageHeader, message interface{}, sender *actor.PID, serializerID int32) {
-	rd := &remoteDeliver{
-		header:       header,
-		message:      message,
-		sender:       sender,
-		target:       pid,
-		serializerID: serializerID,
-	}
-
-	endpointManager.remoteDeliver(rd)
-}
+	"github.com/stretchr/testify/assert"
+)
 
-func (ref *process) SendSystemMessage(pid *actor.PID, message interface{}) {
+func TestSystemsAreIsolated(t *testing.T) {
+	system1 := NewSystem(Config{})
+	defer system1.Shutdown()
+	system2 := NewSystem(Config{})
+	defer system2.Shutdown()
 
-	//intercept any Watch messages and direct them to the endpoint manager
-	switch msg := message.(type) {
-	case *actor.Watch:
-		rw := &remoteWatch{
-			Watcher: msg.Watcher,
-			Watchee: pid,
+	echo := FromFunc(func(ctx Context) {
+		if msg, ok := ctx.Message().(string); ok {
+			ctx.Respond(msg)
 		}
-		endpointManager.remoteWatch(rw)
-	case *actor.Unwatch:
-		ruw := &remoteUnwatch{
-			Watcher: msg.Watcher,
-			Watchee: pid,
-		}
-		endpointManager.remoteUnwatch(ruw)
-	default:
-		SendMessage(pid, nil, message, nil, -1)
-	}
-}
-
-func (ref *process) Stop(pid *actor.PID) {
-	ref.SendSystemMessage(pid, stopMessage)
+	})
+	pid1, err := system1.SpawnNamed(echo, "echo")
+	assert.NoError(t, err)
+	pid2, err := system2.SpawnNamed(echo, "echo")
+	assert.NoError(t, err)
+	assert.NotEqual(t, pid1.Address, pid2.Address)
+
+	res, err := pid1.RequestFuture("hello", time.Second).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", res)
+
+	res, err = system1.NewPID(pid2.Address, pid2.Id).RequestFuture("world", 50*time.Millisecond).Result()
+	assert.Equal(t, ErrTimeout, err)
+
+	// a PID without system resolves through the default system, not through the system owning its address
+	res, err = NewPID(pid2.Address, pid2.Id).RequestFuture("world", 50*time.Millisecond).Result()
+	assert.Equal(t, ErrTimeout, err)
+
+	pid := NewPID(pid2.Address, pid2.Id)
+	system2.Bind(pid)
+	res, err = pid.RequestFuture("world", time.Second).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "world", res)
+
+	_, found := ProcessRegistry.GetLocal("echo")
+	assert.False(t, found)
 }
@@ -0,0 +1,234 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"sync"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+	"github.com/OnyxPay/OnyxChain-eventbus/log"
+)
+
+// OverflowPolicy is what happens to an event for a subscriber whose mailbox is full
+type OverflowPolicy int
+
+const (
+	// OverflowBlock blocks the publisher until the mailbox has space, the event is dropped after Timeout
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest queues up to Capacity events in the hub and drops the oldest queued event when full
+	OverflowDropOldest
+	// OverflowDropNewest drops the event
+	OverflowDropNewest
+	// OverflowDisconnect drops the event and removes every subscription of the subscriber from the hub
+	OverflowDisconnect
+)
+
+// Backpressure configures the overflow policy of a subscriber
+type Backpressure struct {
+	// Load is attached to the mailbox of the subscriber, see MailboxLoad
+	Load *MailboxLoad
+	// Capacity is the mailbox backlog at which the subscriber overflows
+	Capacity int
+	Policy   OverflowPolicy
+	// Timeout is the longest time OverflowBlock blocks the publisher
+	Timeout time.Duration
+}
+
+// OverflowEvent is published on the EventStream whenever the overflow policy of a subscriber triggers,
+// unless the metrics of the hub are disabled
+type OverflowEvent struct {
+	Topic      string
+	Subscriber *actor.PID
+	Policy     OverflowPolicy
+	// Backlog of the subscriber mailbox when the policy triggered
+	Backlog int64
+	// Waited is the time OverflowBlock blocked the publisher
+	Waited time.Duration
+	// Dropped is set when an event was not delivered
+	Dropped bool
+}
+
+// SubscribeWithBackpressure subscribes to the topic like Subscribe and applies the overflow policy of config
+// whenever the mailbox backlog of the subscriber reached its capacity
+func (this *EventHub) SubscribeWithBackpressure(topic string, subscriber *actor.PID, config Backpressure) *SubscriptionHandle {
+	if config.Load == nil {
+		plog.Error("backpressure needs the mailbox load of the subscriber", log.Stringer("pid", subscriber))
+		return this.Subscribe(topic, subscriber)
+	}
+	if config.Capacity < 1 {
+		config.Capacity = 1
+	}
+	return this.subscribe(&subscription{
+		topic:        topic,
+		pid:          subscriber,
+		load:         config.Load,
+		backpressure: &backpressure{Backpressure: config, done: make(chan struct{})},
+	})
+}
+
+// backpressure is the overflow state of a subscription
+type backpressure struct {
+	Backpressure
+	// queue of OverflowDropOldest, drained by a single goroutine while not empty
+	sync.Mutex
+	queue    []*Event
+	draining bool
+	// done is closed once the subscription was removed, ending the drain and the blocked publishers
+	done    chan struct{}
+	stopped bool
+}
+
+// stop discards the queued events and ends the goroutines waiting for the mailbox of the subscriber
+func (bp *backpressure) stop() {
+	bp.Lock()
+	defer bp.Unlock()
+	if !bp.stopped {
+		bp.stopped = true
+		bp.queue = nil
+		close(bp.done)
+	}
+}
+
+func (bp *backpressure) full() bool {
+	return bp.Load.Backlog() >= int64(bp.Capacity)
+}
+
+// send delivers the event to the subscription unless the overflow policy prevents it
+func (bp *backpressure) send(hub *EventHub, sub *subscription, event *Event) {
+	if bp.Policy == OverflowDropOldest {
+		bp.enqueue(hub, sub, event)
+		return
+	}
+	if !bp.full() {
+		deliver(sub.pid, event)
+		return
+	}
+
+	overflow := &OverflowEvent{
+		Topic:      event.Topic,
+		Subscriber: sub.pid,
+		Policy:     bp.Policy,
+		Backlog:    bp.Load.Backlog(),
+		Dropped:    true,
+	}
+	switch bp.Policy {
+	case OverflowBlock:
+		start := time.Now()
+		timeout := time.NewTimer(bp.Timeout)
+		defer timeout.Stop()
+	wait:
+		for {
+			changed := bp.Load.changed()
+			if !bp.full() {
+				overflow.Dropped = false
+				break
+			}
+			select {
+			case <-changed:
+			case <-timeout.C:
+				break wait
+			case <-bp.done:
+				break wait
+			}
+		}
+		overflow.Waited = time.Since(start)
+		if !overflow.Dropped {
+			deliver(sub.pid, event)
+		}
+	case OverflowDisconnect:
+		hub.RemovePID(sub.pid)
+	}
+	hub.overflowed(overflow)
+}
+
+// enqueue queues the event behind the earlier ones, dropping the oldest queued event once the queue is full
+func (bp *backpressure) enqueue(hub *EventHub, sub *subscription, event *Event) {
+	bp.Lock()
+	if bp.stopped {
+		bp.Unlock()
+		return
+	}
+	var dropped *Event
+	if len(bp.queue) >= bp.Capacity {
+		dropped = bp.queue[0]
+		bp.queue = bp.queue[1:]
+	}
+	bp.queue = append(bp.queue, event)
+	start := !bp.draining
+	bp.draining = true
+	bp.Unlock()
+
+	if dropped != nil {
+		hub.overflowed(&OverflowEvent{
+			Topic:      dropped.Topic,
+			Subscriber: sub.pid,
+			Policy:     OverflowDropOldest,
+			Backlog:    bp.Load.Backlog(),
+			Dropped:    true,
+		})
+	}
+	if start {
+		go bp.drain(sub)
+	}
+}
+
+// drain moves the queued events to the mailbox while it has space, until the queue is empty or the subscription removed
+func (bp *backpressure) drain(sub *subscription) {
+	for {
+		changed := bp.Load.changed()
+		if bp.full() {
+			select {
+			case <-changed:
+			case <-bp.done:
+				bp.Lock()
+				bp.draining = false
+				bp.Unlock()
+				return
+			}
+			continue
+		}
+
+		bp.Lock()
+		if bp.stopped || len(bp.queue) == 0 {
+			bp.draining = false
+			bp.Unlock()
+			return
+		}
+		event := bp.queue[0]
+		bp.queue[0] = nil
+		bp.queue = bp.queue[1:]
+		bp.Unlock()
+
+		deliver(sub.pid, event)
+	}
+}
@@ -0,0 +1,192 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+	"github.com/OnyxPay/OnyxChain-eventbus/eventstream"
+	"github.com/OnyxPay/OnyxChain-eventbus/mailbox"
+	"github.com/stretchr/testify/assert"
+)
+
+// spawnBlocked spawns a subscriber processing a message only when release is signalled
+func spawnBlocked(release chan struct{}, received chan int) (*actor.PID, *MailboxLoad) {
+	load := NewMailboxLoad()
+	props := actor.FromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(int); ok {
+			<-release
+			received <- msg
+		}
+	}).WithMailbox(mailbox.Unbounded(load))
+	return actor.Spawn(props), load
+}
+
+func collectOverflows() (func() []*OverflowEvent, *eventstream.Subscription) {
+	var mu sync.Mutex
+	var overflows []*OverflowEvent
+	sub := eventstream.Subscribe(func(msg interface{}) {
+		mu.Lock()
+		overflows = append(overflows, msg.(*OverflowEvent))
+		mu.Unlock()
+	}).WithPredicate(func(msg interface{}) bool {
+		_, ok := msg.(*OverflowEvent)
+		return ok
+	})
+	return func() []*OverflowEvent {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]*OverflowEvent(nil), overflows...)
+	}, sub
+}
+
+// waitUntil polls cond until it holds, it fails the test after a second
+func waitUntil(t *testing.T, cond func() bool, msg string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal(msg)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// waitBacklog waits until the subscriber processed its messages down to a backlog of n
+func waitBacklog(t *testing.T, load *MailboxLoad, n int64) {
+	t.Helper()
+	timeout := time.After(time.Second)
+	for {
+		changed := load.changed()
+		if load.Backlog() <= n {
+			return
+		}
+		select {
+		case <-changed:
+		case <-timeout:
+			t.Fatalf("backlog still %d, expected %d", load.Backlog(), n)
+		}
+	}
+}
+
+// expectReceived waits for the subscriber to process n messages and returns them, the subscriber must not
+// process any other message
+func expectReceived(t *testing.T, load *MailboxLoad, received chan int, n int) []int {
+	t.Helper()
+	var messages []int
+	for len(messages) < n {
+		select {
+		case msg := <-received:
+			messages = append(messages, msg)
+		case <-time.After(time.Second):
+			t.Fatalf("received %v, expected %d messages", messages, n)
+		}
+	}
+	waitBacklog(t, load, 0)
+	assert.Empty(t, received, "unexpected messages after %v", messages)
+	return messages
+}
+
+func TestEventHub_OverflowPolicies(t *testing.T) {
+	for _, policy := range []OverflowPolicy{OverflowBlock, OverflowDropOldest, OverflowDropNewest, OverflowDisconnect} {
+		hub := newTestHub()
+		overflows, sub := collectOverflows()
+		release := make(chan struct{})
+		received := make(chan int, 10)
+		pid, load := spawnBlocked(release, received)
+		hub.SubscribeWithBackpressure("topic", pid, Backpressure{Load: load, Capacity: 2, Policy: policy, Timeout: 20 * time.Millisecond})
+		waitBacklog(t, load, 0)
+
+		//the first two events fill the mailbox
+		hub.Publish(&Event{Topic: "topic", Message: 0})
+		hub.Publish(&Event{Topic: "topic", Message: 1})
+		waitUntil(t, func() bool { return load.Backlog() == 2 }, "the first events did not reach the mailbox")
+		for i := 2; i < 6; i++ {
+			hub.Publish(&Event{Topic: "topic", Message: i})
+		}
+		close(release)
+
+		var expected []int
+		switch policy {
+		case OverflowBlock, OverflowDropNewest:
+			expected = []int{0, 1}
+			assert.Len(t, overflows(), 4, "policy %v", policy)
+		case OverflowDropOldest:
+			expected = []int{0, 1, 4, 5}
+			assert.Len(t, overflows(), 2, "policy %v", policy)
+		case OverflowDisconnect:
+			expected = []int{0, 1}
+			assert.Len(t, overflows(), 1, "policy %v", policy)
+			assert.Empty(t, hub.topic("topic", false).Load())
+		}
+		assert.Equal(t, expected, expectReceived(t, load, received, len(expected)), "policy %v", policy)
+		for _, overflow := range overflows() {
+			assert.True(t, overflow.Dropped)
+			assert.Equal(t, policy, overflow.Policy)
+		}
+		eventstream.Unsubscribe(sub)
+		pid.Stop()
+	}
+}
+
+func TestEventHub_UnsubscribeStopsDrain(t *testing.T) {
+	hub := newTestHub()
+	release := make(chan struct{})
+	received := make(chan int, 10)
+	pid, load := spawnBlocked(release, received)
+	defer pid.Stop()
+	handle := hub.SubscribeWithBackpressure("topic", pid, Backpressure{Load: load, Capacity: 1, Policy: OverflowDropOldest})
+	waitBacklog(t, load, 0)
+
+	//the first event blocks the subscriber with a full mailbox, the others stay queued
+	hub.Publish(&Event{Topic: "topic", Message: 0})
+	waitUntil(t, func() bool { return load.Backlog() == 1 }, "the first event did not reach the mailbox")
+	hub.Publish(&Event{Topic: "topic", Message: 1})
+	hub.Publish(&Event{Topic: "topic", Message: 2})
+	bp := handle.sub.backpressure
+	draining := func() bool {
+		bp.Lock()
+		defer bp.Unlock()
+		return bp.draining
+	}
+	assert.True(t, draining())
+
+	handle.Unsubscribe()
+	waitUntil(t, func() bool { return !draining() }, "drain still running after unsubscribe")
+
+	hub.Publish(&Event{Topic: "topic", Message: 3})
+	close(release)
+	assert.Equal(t, []int{0}, expectReceived(t, load, received, 1))
+}
@@ -0,0 +1,350 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"strconv"
+	"sync/atomic"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+	"github.com/OnyxPay/OnyxChain-eventbus/log"
+)
+
+// ClusterPeerName is the default name of the actor through which the EventHubs of a cluster exchange
+// subscriptions and events, see WithClusterName
+const ClusterPeerName = "EventHub"
+
+const (
+	topicHeader  = "eventhub-topic"
+	policyHeader = "eventhub-policy"
+)
+
+// localTopicChanged makes the cluster peer count the local subscriptions of the topic again
+type localTopicChanged struct {
+	topic ClusterTopic
+}
+
+// clusterStart makes the cluster peer count the local subscriptions and connect to the seeds
+type clusterStart struct{}
+
+type nodeConnected struct {
+	address string
+}
+
+type nodeTerminated struct {
+	address string
+}
+
+// clusterPeer gossips the local subscriptions of a hub to the other nodes and publishes the events
+// forwarded by them to the local subscribers
+type clusterPeer struct {
+	hub    *EventHub
+	name   string
+	seeds  []string
+	topics map[ClusterTopic]bool            // topics and selectors with local subscriptions
+	nodes  map[string]map[ClusterTopic]bool // topics subscribed by remote node address
+}
+
+// EnableCluster turns the hub into a member of a cluster of EventHubs, one per node.
+//
+// Remote subscriptions are gossiped to the nodes known from seeds and from connected remote endpoints,
+// and a published event is forwarded once to every node with matching subscribers, where it is
+// delivered according to the event policy. Selectors of remote subscriptions are evaluated before forwarding,
+// predicates only on the node of the subscriber. Forwarded messages must be serializable by remote.
+//
+// The peer is spawned in the ActorSystem of the hub with the cluster name of the hub, it exchanges subscriptions
+// with the peers of the same name on the other nodes. The remoting of the system must be started first
+func (this *EventHub) EnableCluster(seeds ...string) error {
+	name := this.clusterName()
+	props := actor.FromProducer(func() actor.Actor {
+		return &clusterPeer{hub: this, name: name, seeds: seeds}
+	})
+	pid, err := this.system().SpawnNamed(props, name)
+	if err != nil {
+		return err
+	}
+	this.peer.Store(pid)
+	//the subscriptions made before the peer was stored are counted once it starts
+	pid.Tell(&clusterStart{})
+	return nil
+}
+
+// clusterPeer returns the cluster peer of the hub, nil unless EnableCluster was called
+func (this *EventHub) clusterPeer() *actor.PID {
+	pid, _ := this.peer.Load().(*actor.PID)
+	return pid
+}
+
+// NodeConnected exchanges subscriptions with the hub on the node at address, it is a no-op unless
+// the cluster is enabled
+func (this *EventHub) NodeConnected(address string) {
+	if peer := this.clusterPeer(); peer != nil {
+		peer.Tell(&nodeConnected{address: address})
+	}
+}
+
+// gossipChanged tells the cluster peer about added or removed local subscriptions
+func (this *EventHub) gossipChanged(subs []*subscription) {
+	peer := this.clusterPeer()
+	if peer == nil {
+		return
+	}
+	for _, sub := range subs {
+		if !sub.node {
+			peer.Tell(&localTopicChanged{topic: ClusterTopic{Topic: sub.topic, Selector: sub.selector}})
+		}
+	}
+}
+
+// localSubscriptions counts the local subscriptions to the topic with its selector
+func (this *EventHub) localSubscriptions(topic ClusterTopic) int {
+	var subs []*subscription
+	if this.isPattern(topic.Topic) {
+		subs = this.wildcards.Subscriptions(topic.Topic)
+	} else if ts := this.topic(topic.Topic, false); ts != nil {
+		subs = ts.Load()
+	}
+	n := 0
+	for _, sub := range subs {
+		if !sub.node && sub.selector == topic.Selector {
+			n++
+		}
+	}
+	return n
+}
+
+// localTopics returns the topics and selectors of all local subscriptions
+func (this *EventHub) localTopics() map[ClusterTopic]bool {
+	topics := make(map[ClusterTopic]bool)
+	add := func(sub *subscription) {
+		if !sub.node {
+			topics[ClusterTopic{Topic: sub.topic, Selector: sub.selector}] = true
+		}
+	}
+	this.wildcards.Each(add)
+	for item := range this.subscribers.IterBuffered() {
+		for _, sub := range item.Val.(*topicSubscribers).Load() {
+			add(sub)
+		}
+	}
+	return topics
+}
+
+// nodeSubscriptions drops the subscriptions of remote nodes when localOnly is set, otherwise it keeps
+// a single subscription per node so that a node matching several topic patterns or selectors gets the event once
+func nodeSubscriptions(subs []*subscription, localOnly bool) []*subscription {
+	var nodes map[string]bool
+	return without(subs, func(sub *subscription) bool {
+		if !sub.node {
+			return false
+		}
+		if localOnly || nodes[sub.key] {
+			return true
+		}
+		if nodes == nil {
+			nodes = make(map[string]bool)
+		}
+		nodes[sub.key] = true
+		return false
+	})
+}
+
+// forward sends the event to the cluster peer of a remote node, which delivers it with the policy
+func forward(peer *actor.PID, event *Event, policy PublishPolicy) {
+	env := envelope(event)
+	env.SetHeader(topicHeader, event.Topic)
+	env.SetHeader(policyHeader, strconv.Itoa(int(policy)))
+	peer.Tell(env)
+}
+
+func (state *clusterPeer) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		state.topics = make(map[ClusterTopic]bool)
+		state.nodes = make(map[string]map[ClusterTopic]bool)
+	case *clusterStart:
+		for topic := range state.hub.localTopics() {
+			state.topics[topic] = true
+		}
+		for _, address := range state.seeds {
+			state.connect(address)
+		}
+	case *localTopicChanged:
+		//counting instead of tracking deltas is exact whichever subscriptions were made before the peer started
+		subscribed := state.hub.localSubscriptions(msg.topic) > 0
+		switch {
+		case subscribed && !state.topics[msg.topic]:
+			state.topics[msg.topic] = true
+			state.broadcast(&ClusterSubscriptions{Address: state.address(), Subscribed: []*ClusterTopic{&msg.topic}})
+		case !subscribed && state.topics[msg.topic]:
+			delete(state.topics, msg.topic)
+			state.broadcast(&ClusterSubscriptions{Address: state.address(), Unsubscribed: []*ClusterTopic{&msg.topic}})
+		}
+	case *nodeConnected:
+		if _, ok := state.nodes[msg.address]; !ok {
+			state.connect(msg.address)
+		}
+	case *nodeTerminated:
+		//the subscriptions of the node were removed together with the other subscribers at its address
+		delete(state.nodes, msg.address)
+	case *ClusterSubscriptions:
+		_, known := state.nodes[msg.Address]
+		state.update(msg)
+		if msg.Request || !known {
+			//a node unknown so far also needs our subscriptions, and its own if it sent only changes
+			state.peer(msg.Address).Tell(state.snapshot(!known && !msg.Snapshot))
+		}
+	case actor.SystemMessage, actor.AutoReceiveMessage:
+		//ignore
+	default:
+		header := ctx.MessageHeader().ToMap()
+		topic := header[topicHeader]
+		if topic == "" {
+			plog.Error("EventHub cluster peer received unknown message", log.TypeOf("type", msg), log.Message(msg))
+			return
+		}
+		policy, _ := strconv.Atoi(header[policyHeader])
+		//the offset of the publishing node is kept unless the topic is retained here as well
+		offset, _ := strconv.ParseUint(header[OffsetHeader], 10, 64)
+		delete(header, topicHeader)
+		delete(header, policyHeader)
+		delete(header, OffsetHeader)
+		err := state.hub.publish(&Event{
+			Publisher: ctx.Sender(),
+			Topic:     topic,
+			Message:   msg,
+			Policy:    PublishPolicy(policy),
+			Offset:    offset,
+			Header:    header,
+		}, true)
+		if err != nil {
+			plog.Error("failed to publish forwarded event", log.String("topic", topic), log.Error(err))
+		}
+	}
+}
+
+func (state *clusterPeer) peer(address string) *actor.PID {
+	return state.hub.system().NewPID(address, state.name)
+}
+
+// address returns the address of the node, set by the remoting of the system
+func (state *clusterPeer) address() string {
+	return state.hub.system().ProcessRegistry.Address
+}
+
+// connect registers the node and exchanges snapshots with it
+func (state *clusterPeer) connect(address string) {
+	if address == state.address() {
+		return
+	}
+	if _, ok := state.nodes[address]; !ok {
+		state.nodes[address] = make(map[ClusterTopic]bool)
+	}
+	state.peer(address).Tell(state.snapshot(true))
+}
+
+func (state *clusterPeer) snapshot(request bool) *ClusterSubscriptions {
+	topics := make([]*ClusterTopic, 0, len(state.topics))
+	for topic := range state.topics {
+		topic := topic
+		topics = append(topics, &topic)
+	}
+	return &ClusterSubscriptions{
+		Address:    state.address(),
+		Subscribed: topics,
+		Snapshot:   true,
+		Request:    request,
+	}
+}
+
+func (state *clusterPeer) broadcast(msg *ClusterSubscriptions) {
+	for address := range state.nodes {
+		state.peer(address).Tell(msg)
+	}
+}
+
+// update applies the subscriptions gossiped by a node to the hub
+func (state *clusterPeer) update(msg *ClusterSubscriptions) {
+	topics, ok := state.nodes[msg.Address]
+	if !ok || msg.Snapshot {
+		if ok {
+			for topic := range topics {
+				state.unsubscribe(msg.Address, topic)
+			}
+		}
+		topics = make(map[ClusterTopic]bool)
+		state.nodes[msg.Address] = topics
+	}
+
+	for _, topic := range msg.Subscribed {
+		if topics[*topic] {
+			continue
+		}
+		topics[*topic] = true
+		state.subscribe(msg.Address, *topic)
+	}
+	for _, topic := range msg.Unsubscribed {
+		if topics[*topic] {
+			delete(topics, *topic)
+			state.unsubscribe(msg.Address, *topic)
+		}
+	}
+}
+
+func (state *clusterPeer) subscribe(address string, topic ClusterTopic) {
+	var filter Predicate
+	if topic.Selector != "" {
+		sel, err := parseSelector(topic.Selector)
+		if err != nil {
+			//forwarding everything is safe, the node filters again before delivery
+			plog.Error("EventHub cluster peer received invalid selector", log.String("address", address), log.Error(err))
+		} else {
+			filter = headerFilter(sel)
+		}
+	}
+	pid := state.peer(address)
+	state.hub.add(&subscription{
+		id:       atomic.AddUint64(&state.hub.sequence, 1),
+		key:      pidKey(pid),
+		pid:      pid,
+		topic:    topic.Topic,
+		node:     true,
+		filter:   filter,
+		selector: topic.Selector,
+	})
+}
+
+func (state *clusterPeer) unsubscribe(address string, topic ClusterTopic) {
+	state.hub.unsubscribe(topic.Topic, func(sub *subscription) bool {
+		return sub.node && sub.pid.Address == address && sub.selector == topic.Selector
+	})
+}
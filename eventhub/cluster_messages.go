@@ -0,0 +1,67 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"github.com/gogo/protobuf/proto"
+)
+
+// ClusterTopic is a topic, or topic pattern, together with the header selector of its subscribers
+type ClusterTopic struct {
+	Topic    string `protobuf:"bytes,1,opt,name=Topic,proto3" json:"Topic,omitempty"`
+	Selector string `protobuf:"bytes,2,opt,name=Selector,proto3" json:"Selector,omitempty"`
+}
+
+func (m *ClusterTopic) Reset()         { *m = ClusterTopic{} }
+func (m *ClusterTopic) String() string { return proto.CompactTextString(m) }
+func (*ClusterTopic) ProtoMessage()    {}
+
+// ClusterSubscriptions is exchanged between the cluster peers of EventHubs to gossip the topics,
+// or topic patterns, which have local subscribers on the node at Address
+type ClusterSubscriptions struct {
+	Address      string          `protobuf:"bytes,1,opt,name=Address,proto3" json:"Address,omitempty"`
+	Subscribed   []*ClusterTopic `protobuf:"bytes,2,rep,name=Subscribed" json:"Subscribed,omitempty"`
+	Unsubscribed []*ClusterTopic `protobuf:"bytes,3,rep,name=Unsubscribed" json:"Unsubscribed,omitempty"`
+	// Snapshot replaces everything previously received from the node with Subscribed
+	Snapshot bool `protobuf:"varint,4,opt,name=Snapshot,proto3" json:"Snapshot,omitempty"`
+	// Request asks the receiver to answer with a snapshot of its own subscriptions
+	Request bool `protobuf:"varint,5,opt,name=Request,proto3" json:"Request,omitempty"`
+}
+
+func (m *ClusterSubscriptions) Reset()         { *m = ClusterSubscriptions{} }
+func (m *ClusterSubscriptions) String() string { return proto.CompactTextString(m) }
+func (*ClusterSubscriptions) ProtoMessage()    {}
+
+func init() {
+	proto.RegisterType((*ClusterTopic)(nil), "eventhub.ClusterTopic")
+	proto.RegisterType((*ClusterSubscriptions)(nil), "eventhub.ClusterSubscriptions")
+}
@@ -0,0 +1,88 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/eventhub"
+	"github.com/OnyxPay/OnyxChain-eventbus/remotetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventHub_ClusterOverRemote(t *testing.T) {
+	cluster, err := remotetest.NewCluster(remotetest.GRPC, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cluster.Shutdown()
+	node0, node1 := cluster.Node(0), cluster.Node(1)
+
+	//two clusters of hubs on the same nodes, the subscriptions are made before the clusters are enabled
+	hubs := make([][2]*eventhub.EventHub, 2)
+	for i, name := range []string{eventhub.ClusterPeerName, "blocks"} {
+		hubs[i][0] = eventhub.New(eventhub.WithSystem(node0.System), eventhub.WithClusterName(name))
+		hubs[i][1] = eventhub.New(eventhub.WithSystem(node1.System), eventhub.WithClusterName(name))
+	}
+	events := node1.NewProbe(t)
+	defer events.Stop()
+	blocks := node1.NewProbe(t)
+	defer blocks.Stop()
+	hubs[0][1].Subscribe("event.#", events.PID())
+	hubs[1][1].Subscribe("block.#", blocks.PID())
+
+	for _, pair := range hubs {
+		assert.NoError(t, pair[0].EnableCluster(node1.Address))
+		assert.NoError(t, pair[1].EnableCluster(node0.Address))
+	}
+
+	msg := remotetest.Eventually(t, events, func() {
+		hubs[0][0].Publish(&eventhub.Event{Topic: "event.added", Message: &eventhub.ClusterTopic{Topic: "event"}})
+	}, 10*time.Second)
+	assert.Equal(t, &eventhub.ClusterTopic{Topic: "event"}, msg)
+	msg = remotetest.Eventually(t, blocks, func() {
+		hubs[1][0].Publish(&eventhub.Event{Topic: "block.added", Message: &eventhub.ClusterTopic{Topic: "block"}})
+	}, 10*time.Second)
+	assert.Equal(t, &eventhub.ClusterTopic{Topic: "block"}, msg)
+
+	//the duplicates published while waiting may still arrive
+	for {
+		if _, ok := blocks.Receive(200 * time.Millisecond); !ok {
+			break
+		}
+	}
+
+	//each cluster only forwards its own topics
+	hubs[0][0].Publish(&eventhub.Event{Topic: "block.added", Message: &eventhub.ClusterTopic{Topic: "other cluster"}})
+	blocks.ExpectNoMsg(200 * time.Millisecond)
+}
@@ -0,0 +1,113 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventHub_ForwardsOncePerNode(t *testing.T) {
+	hub := newTestHub()
+	hub.peer.Store(spawnCounter(nil, nil))
+
+	var wg sync.WaitGroup
+	var local, node int64
+	hub.Subscribe("block.added", spawnCounter(&wg, &local))
+	nodePeer := spawnCounter(&wg, &node)
+	for i, topic := range []string{"block.added", "block.*", "block.#"} {
+		hub.add(&subscription{id: uint64(100 + i), key: pidKey(nodePeer), pid: nodePeer, topic: topic, node: true})
+	}
+
+	wg.Add(2)
+	hub.Publish(&Event{Topic: "block.added", Message: "hello", Policy: PublishPolicyAll})
+	waitTimeout(t, &wg, time.Second)
+
+	wg.Add(1)
+	hub.publish(&Event{Topic: "block.added", Message: "hello", Policy: PublishPolicyAll}, true)
+	waitTimeout(t, &wg, time.Second)
+
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int64(2), local)
+	assert.Equal(t, int64(1), node)
+}
+
+func TestClusterPeer_Update(t *testing.T) {
+	hub := newTestHub()
+	state := &clusterPeer{hub: hub, topics: make(map[ClusterTopic]bool), nodes: make(map[string]map[ClusterTopic]bool)}
+	nodeSubscriptions := func() int {
+		n := len(hub.wildcards.Match("block.added"))
+		if ts := hub.topic("block.added", false); ts != nil {
+			n += len(ts.Load())
+		}
+		return n
+	}
+
+	state.update(&ClusterSubscriptions{Address: "node1", Subscribed: []*ClusterTopic{{Topic: "block.added"}, {Topic: "block.#"}}, Snapshot: true})
+	state.update(&ClusterSubscriptions{Address: "node1", Subscribed: []*ClusterTopic{{Topic: "block.added"}}})
+	assert.Equal(t, 2, nodeSubscriptions())
+
+	state.update(&ClusterSubscriptions{Address: "node1", Unsubscribed: []*ClusterTopic{{Topic: "block.#"}}})
+	assert.Equal(t, 1, nodeSubscriptions())
+
+	state.update(&ClusterSubscriptions{Address: "node1", Subscribed: []*ClusterTopic{{Topic: "block.*"}}, Snapshot: true})
+	assert.Equal(t, 1, nodeSubscriptions())
+	assert.Equal(t, map[ClusterTopic]bool{{Topic: "block.*"}: true}, state.nodes["node1"])
+
+	hub.RemoveAddress("node1")
+	assert.Equal(t, 0, nodeSubscriptions())
+}
+
+func TestEventHub_ForwardsByNodeSelector(t *testing.T) {
+	hub := newTestHub()
+	hub.peer.Store(spawnCounter(nil, nil))
+	state := &clusterPeer{hub: hub, topics: make(map[ClusterTopic]bool), nodes: make(map[string]map[ClusterTopic]bool)}
+	state.update(&ClusterSubscriptions{Address: "node1", Subscribed: []*ClusterTopic{
+		{Topic: "block.added", Selector: "type = 'a'"},
+		{Topic: "block.#", Selector: "type IN ('a', 'b')"},
+	}})
+
+	match := func(header map[string]string) []*subscription {
+		subs := append(append([]*subscription(nil), hub.topic("block.added", false).Load()...), hub.wildcards.Match("block.added")...)
+		event := &Event{Topic: "block.added", Header: header}
+		return nodeSubscriptions(without(subs, func(sub *subscription) bool {
+			return sub.filter != nil && !sub.filter(event)
+		}), false)
+	}
+	assert.Len(t, match(map[string]string{"type": "a"}), 1)
+	assert.Len(t, match(map[string]string{"type": "b"}), 1)
+	assert.Len(t, match(map[string]string{"type": "c"}), 0)
+	assert.Len(t, match(nil), 0)
+}
@@ -33,6 +33,10 @@ package eventhub
 
 import (
 	"math/rand"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
 
 	"github.com/OnyxPay/OnyxChain-eventbus/actor"
 	"github.com/orcaman/concurrent-map"
@@ -40,20 +44,45 @@ import (
 
 type PublishPolicy int
 
-type RoundRobinState struct {
-	state map[string]int
-}
-
 const (
 	PublishPolicyAll = iota
 	PublishPolicyRoundRobin
 	PublishPolicyRandom
+	// PublishPolicyConsistentHash sends events with the same router.Hasher key to the same subscriber
+	PublishPolicyConsistentHash
+	// PublishPolicyLeastLoaded sends each event to the subscriber with the smallest mailbox backlog
+	PublishPolicyLeastLoaded
 )
 
+// PublishPolicyDefault is replaced by the default policy of the hub, PublishPolicyAll unless set with WithDefaultPolicy.
+//
+// It is outside the range of the other policies to keep their values, the zero Policy of an Event is PublishPolicyAll
+const PublishPolicyDefault = -1
+
+// EventHub routes published events to the actors subscribed to the event topic.
+//
+// subscribers maps a topic to its *topicSubscribers. Every topic holds an immutable snapshot of its subscriptions
+// which is replaced atomically on Subscribe and Unsubscribe, so Publish never takes a lock to read it.
+//
+// The zero value is a hub with the default options, New creates hubs with other options.
 type EventHub struct {
-	//sync.RWMutex
-	Subscribers cmap.ConcurrentMap
-	RoundRobinState
+	// sequence of the subscription ids, only accessed atomically, first for 64-bit alignment
+	sequence uint64
+	// initOnce sets up the fields below with the default options unless New already did
+	initOnce    sync.Once
+	subscribers cmap.ConcurrentMap
+	wildcards   *topicTrie
+	// watchSubscribers makes the hub watch its subscribers and remove them once they terminated
+	watchSubscribers bool
+	watcherOnce      sync.Once
+	watcher          *actor.PID
+	// peer holds the *actor.PID of the cluster peer of the hub once EnableCluster was called
+	peer atomic.Value
+	// groups maps a topic and group name to the consumer group actor
+	groupsMu sync.Mutex
+	groups   map[groupKey]*actor.PID
+	config   *hubConfig
+	metrics  *hubMetrics
 }
 
 type Event struct {
@@ -61,85 +90,355 @@ type Event struct {
 	Topic     string
 	Message   interface{}
 	Policy    PublishPolicy
+	// Offset is stamped on events published to a retained topic, it is zero for other topics
+	Offset uint64
+	// Header is delivered with the message as MessageEnvelope header and evaluated by subscription selectors
+	Header map[string]string
 }
 
-var GlobalEventHub = &EventHub{Subscribers: cmap.New(), RoundRobinState: RoundRobinState{make(map[string]int)}}
+// topicSubscribers is the copy-on-write subscription list of a single topic
+type topicSubscribers struct {
+	// serializes writers, readers only load the snapshot
+	sync.Mutex
+	snapshot atomic.Value
+	policyState
+	// durable serializes publishing and replaying of a retained topic
+	durable sync.Mutex
+	log     atomic.Value
+	// messageType holds the *messageType of a typed topic
+	messageType atomic.Value
+}
 
-func (this *EventHub) Publish(event *Event) {
-	//go func() {
-	actors, ok := this.Subscribers.Get(event.Topic)
-	if !ok {
-		return
+func newTopicSubscribers() *topicSubscribers {
+	ts := &topicSubscribers{}
+	ts.snapshot.Store([]*subscription(nil))
+	return ts
+}
+
+// Load returns the current subscriptions, the returned slice must not be modified
+func (ts *topicSubscribers) Load() []*subscription {
+	return ts.snapshot.Load().([]*subscription)
+}
+
+func (ts *topicSubscribers) add(sub *subscription) {
+	ts.Lock()
+	defer ts.Unlock()
+
+	old := ts.Load()
+	subs := make([]*subscription, len(old), len(old)+1)
+	copy(subs, old)
+	ts.snapshot.Store(append(subs, sub))
+}
+
+// remove removes the subscriptions accepted by match and returns them
+func (ts *topicSubscribers) remove(match func(*subscription) bool) []*subscription {
+	ts.Lock()
+	defer ts.Unlock()
+
+	old := ts.Load()
+	subs := make([]*subscription, 0, len(old))
+	var removed []*subscription
+	for _, sub := range old {
+		if match(sub) {
+			removed = append(removed, sub)
+		} else {
+			subs = append(subs, sub)
+		}
 	}
-	subscribers := actors.([]*actor.PID)
-	this.sendEventByPolicy(subscribers, event, this.RoundRobinState)
-	//}()
+	if len(removed) > 0 {
+		ts.snapshot.Store(subs)
+	}
+	return removed
+}
+
+// messageType is the message type of a typed topic
+type messageType struct {
+	typ     reflect.Type
+	accepts func(message interface{}) bool
 }
 
-func (this *EventHub) Subscribe(topic string, subscriber *actor.PID) {
-	subscribers, _ := this.Subscribers.Get(topic)
+// GlobalEventHub is the hub of the process, created with the default options
+var GlobalEventHub = New()
 
-	//defer this.RWMutex.Unlock()
-	//this.RWMutex.Lock()
-	if subscribers == nil {
-		this.Subscribers.Set(topic, []*actor.PID{subscriber})
-	} else {
-		this.Subscribers.Set(topic, append(subscribers.([]*actor.PID), subscriber))
+// New creates an EventHub independent of any other hub
+func New(options ...Option) *EventHub {
+	config := defaultHubConfig()
+	for _, option := range options {
+		option(config)
+	}
+
+	hub := &EventHub{}
+	hub.initOnce.Do(func() {
+		hub.configure(config)
+	})
+	return hub
+}
+
+// init configures a hub created as zero value with the default options
+func (this *EventHub) init() {
+	this.initOnce.Do(func() {
+		this.configure(defaultHubConfig())
+	})
+}
+
+func (this *EventHub) configure(config *hubConfig) {
+	this.subscribers = cmap.New()
+	this.wildcards = newTopicTrie()
+	this.watchSubscribers = config.watchSubscribers
+	this.config = config
+	if config.metrics {
+		this.metrics = &hubMetrics{}
+	}
+}
+
+// topic returns the subscription list of the topic, creating it when create is set.
+//
+// Topics are never removed from the map, so a list returned here can always be written to
+func (this *EventHub) topic(name string, create bool) *topicSubscribers {
+	this.init()
+	if ts, ok := this.subscribers.Get(name); ok {
+		return ts.(*topicSubscribers)
+	}
+	if !create {
+		return nil
+	}
+	this.subscribers.SetIfAbsent(name, newTopicSubscribers())
+	ts, _ := this.subscribers.Get(name)
+	return ts.(*topicSubscribers)
+}
+
+// Publish sends the event to the subscribers of its topic according to the event policy,
+// an event with PublishPolicyDefault gets the default policy of the hub.
+//
+// In cluster mode the event is forwarded once to every remote node with matching subscribers.
+// An event of a typed topic with a message of another type is not sent and Publish returns ErrTopicType.
+// The event of a retained topic is not sent when the retention log fails to store it, Publish returns the error
+func (this *EventHub) Publish(event *Event) error {
+	return this.publish(event, false)
+}
+
+// publish sends the event to the matching subscriptions, localOnly excludes the remote nodes
+func (this *EventHub) publish(event *Event, localOnly bool) error {
+	return this.dispatch(event, localOnly, this.send)
+}
+
+// dispatch calls send with the subscriptions chosen by the event policy among the matching ones,
+// it returns ErrTopicType or the error of the retention log
+func (this *EventHub) dispatch(event *Event, localOnly bool, send func(*subscription, *Event)) error {
+	this.init()
+	policy := this.policy(event)
+	if this.metrics != nil {
+		atomic.AddUint64(&this.metrics.published, 1)
+	}
+
+	ts := this.topic(event.Topic, false)
+	if ts != nil {
+		if typ, ok := ts.messageType.Load().(*messageType); ok && !typ.accepts(event.Message) {
+			return ErrTopicType
+		}
+		if retention := ts.retention(); retention != nil {
+			ts.durable.Lock()
+			defer ts.durable.Unlock()
+			retained, err := retention.Append(event)
+			if err != nil {
+				return err
+			}
+			//the subscribers get the offset of the retained copy
+			event = retained
+		}
+	}
+
+	var subs []*subscription
+	var state *policyState
+	if ts != nil {
+		subs = ts.Load()
+		state = &ts.policyState
 	}
+	if !this.wildcards.Empty() {
+		if matched, node := this.wildcards.match(event.Topic); len(matched) > 0 {
+			//copy, the snapshot is shared with concurrent publishers
+			subs = append(append(make([]*subscription, 0, len(subs)+len(matched)), subs...), matched...)
+			//a topic matched only by patterns keeps no state of its own, which would grow with every concrete topic
+			if state == nil {
+				state = node
+			}
+		}
+	}
+	subs = without(subs, func(sub *subscription) bool {
+		return sub.filter != nil && !sub.filter(event)
+	})
+	if this.clusterPeer() != nil {
+		subs = nodeSubscriptions(subs, localOnly)
+	}
+	if len(subs) == 0 {
+		return nil
+	}
+	for _, sub := range selectByPolicy(subs, policy, event, state) {
+		send(sub, event)
+	}
+	return nil
+}
 
+// Subscribe registers the subscriber for the topic and returns a handle to the registration.
+//
+// Topic levels are separated by TopicSeparator. A level of SingleLevelWildcard matches exactly one level
+// and a level of MultiLevelWildcard matches zero or more levels, e.g. "block.*" or "block.#"
+func (this *EventHub) Subscribe(topic string, subscriber *actor.PID) *SubscriptionHandle {
+	return this.subscribe(&subscription{topic: topic, pid: subscriber})
 }
 
+// subscribe registers the local subscription, setting its id and key
+func (this *EventHub) subscribe(sub *subscription) *SubscriptionHandle {
+	sub.id = atomic.AddUint64(&this.sequence, 1)
+	sub.key = pidKey(sub.pid)
+
+	this.add(sub)
+	if this.watchSubscribers {
+		this.subscriberWatcher().Tell(&subscriberAdded{pid: sub.pid})
+	}
+	this.gossipChanged([]*subscription{sub})
+	return &SubscriptionHandle{hub: this, sub: sub}
+}
+
+func (this *EventHub) add(sub *subscription) {
+	if this.isPattern(sub.topic) {
+		this.wildcards.Insert(sub.topic, sub)
+	} else {
+		this.topic(sub.topic, true).add(sub)
+	}
+}
+
+// Unsubscribe removes every subscription of the subscriber to the topic.
+//
+// Subscribers are compared by Address and Id, so any PID equal to the subscribed one can be used
 func (this *EventHub) Unsubscribe(topic string, subscriber *actor.PID) {
+	this.unsubscribe(topic, func(sub *subscription) bool {
+		return sub.is(subscriber)
+	})
+}
 
-	tmpslice, ok := this.Subscribers.Get(topic)
-	if !ok {
+func (this *EventHub) unsubscribe(topic string, match func(*subscription) bool) {
+	if this.isPattern(topic) {
+		this.removed(this.wildcards.Remove(topic, match))
 		return
 	}
-	//defer this.RWMutex.Unlock()
-	//this.RWMutex.Lock()
-	subscribers := tmpslice.([]*actor.PID)
-	for i, s := range subscribers {
-		if s == subscriber {
-			this.Subscribers.Set(topic, append(subscribers[0:i], subscribers[i+1:]...))
-			return
+
+	if ts := this.topic(topic, false); ts != nil {
+		this.removed(ts.remove(match))
+	}
+}
+
+// remove removes the subscriptions accepted by match from all topics and returns them
+func (this *EventHub) remove(match func(*subscription) bool) []*subscription {
+	this.init()
+	removed := this.wildcards.RemoveAll(match)
+	for item := range this.subscribers.IterBuffered() {
+		removed = append(removed, item.Val.(*topicSubscribers).remove(match)...)
+	}
+	return removed
+}
+
+// removed notifies the subscriber watcher and the cluster peer about removed subscriptions
+func (this *EventHub) removed(subs []*subscription) {
+	for _, sub := range subs {
+		sub.close()
+		if sub.node {
+			continue
+		}
+		if this.watchSubscribers {
+			this.subscriberWatcher().Tell(&subscriberRemoved{pid: sub.pid})
 		}
 	}
+	this.gossipChanged(subs)
+}
 
+// policy returns the policy of the event, the default policy of the hub for PublishPolicyDefault.
+//
+// The event is not modified, it may be shared by concurrent publishers
+func (this *EventHub) policy(event *Event) PublishPolicy {
+	if event.Policy == PublishPolicyDefault {
+		return this.config.defaultPolicy
+	}
+	return event.Policy
 }
 
-func (this *EventHub) sendEventByPolicy(subscribers []*actor.PID, event *Event, state RoundRobinState) {
-	switch event.Policy {
+// selectByPolicy returns the subscriptions the policy sends the event to
+func selectByPolicy(subs []*subscription, policy PublishPolicy, event *Event, state *policyState) []*subscription {
+	var i int
+	switch policy {
 	case PublishPolicyAll:
-		for _, subscriber := range subscribers {
-			subscriber.Request(event.Message, event.Publisher)
-		}
+		return subs
 	case PublishPolicyRandom:
-		length := len(subscribers)
-		if length == 0 {
-			return
-		}
-		var i int
-		i = rand.Intn(length)
-		subscribers[i].Request(event.Message, event.Publisher)
+		i = rand.Intn(len(subs))
 	case PublishPolicyRoundRobin:
-		latestIdx := state.state[event.Topic]
-		i := latestIdx + 1
-		if i < 0 {
-			latestIdx = 0
-			i = 0
+		i = int((atomic.AddUint64(&state.next, 1) - 1) % uint64(len(subs)))
+	case PublishPolicyConsistentHash:
+		if i = state.hashed(subs, event); i < 0 {
+			return nil
+		}
+	case PublishPolicyLeastLoaded:
+		i = state.leastLoaded(subs)
+	default:
+		return nil
+	}
+	return subs[i : i+1]
+}
+
+func (this *EventHub) send(sub *subscription, event *Event) {
+	if this.metrics != nil {
+		if sub.node {
+			atomic.AddUint64(&this.metrics.forwarded, 1)
+		} else {
+			atomic.AddUint64(&this.metrics.sent, 1)
 		}
-		state.state[event.Topic] = i
-		mod := len(subscribers)
-		subscribers[i%mod].Request(event.Message, event.Publisher)
+	}
+	switch {
+	case sub.node:
+		forward(sub.pid, event, this.policy(event))
+	case sub.backpressure != nil:
+		sub.backpressure.send(this, sub, event)
+	default:
+		deliver(sub.pid, event)
 	}
 }
 
-func (this *EventHub) RemovePID(pid actor.PID) {
-	if this.Subscribers.Count() == 0 {
+// deliver sends the event to a local subscriber
+func deliver(subscriber *actor.PID, event *Event) {
+	if event.Offset == 0 && len(event.Header) == 0 {
+		subscriber.Request(event.Message, event.Publisher)
 		return
 	}
-	keys := this.Subscribers.Keys()
-	for index, _ := range keys {
-		this.Unsubscribe(keys[index], &pid)
+	subscriber.Tell(envelope(event))
+}
+
+// envelope wraps the message of the event together with its header and offset
+func envelope(event *Event) *actor.MessageEnvelope {
+	env := &actor.MessageEnvelope{
+		Message: event.Message,
+		Sender:  event.Publisher,
+	}
+	for key, value := range event.Header {
+		env.SetHeader(key, value)
+	}
+	if event.Offset != 0 {
+		env.SetHeader(OffsetHeader, strconv.FormatUint(event.Offset, 10))
+	}
+	return env
+}
+
+// RemovePID removes every subscription of the pid from all topics
+func (this *EventHub) RemovePID(pid *actor.PID) {
+	this.removed(this.remove(func(sub *subscription) bool {
+		return sub.is(pid)
+	}))
+}
+
+// RemoveAddress removes every subscription of the PIDs located at address, e.g. when a remote endpoint terminated
+func (this *EventHub) RemoveAddress(address string) {
+	this.removed(this.remove(func(sub *subscription) bool {
+		return sub.pid.Address == address
+	}))
+	if peer := this.clusterPeer(); peer != nil {
+		peer.Tell(&nodeTerminated{address: address})
 	}
 }
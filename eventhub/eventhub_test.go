@@ -0,0 +1,272 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+	"github.com/OnyxPay/OnyxChain-eventbus/eventstream"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestHub() *EventHub {
+	return New(WithSubscriberWatch(false))
+}
+
+// countingProcess counts the string messages it receives without going through a mailbox
+type countingProcess struct {
+	wg    *sync.WaitGroup
+	count *int64
+}
+
+func (p *countingProcess) SendUserMessage(pid *actor.PID, message interface{}) {
+	if _, msg, _ := actor.UnwrapEnvelope(message); msg != nil {
+		if _, ok := msg.(string); ok && p.count != nil {
+			atomic.AddInt64(p.count, 1)
+			p.wg.Done()
+		}
+	}
+}
+
+func (p *countingProcess) SendSystemMessage(pid *actor.PID, message interface{}) {}
+
+func (p *countingProcess) Stop(pid *actor.PID) {
+	actor.ProcessRegistry.Remove(pid)
+}
+
+func spawnCounter(wg *sync.WaitGroup, count *int64) *actor.PID {
+	pid, _ := actor.ProcessRegistry.Add(&countingProcess{wg: wg, count: count}, "counter"+actor.ProcessRegistry.NextId())
+	return pid
+}
+
+func waitTimeout(t *testing.T, wg *sync.WaitGroup, d time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(d):
+		assert.Fail(t, "timed out waiting for deliveries")
+	}
+}
+
+func TestEventHub_ZeroValue(t *testing.T) {
+	hub := &EventHub{}
+	var wg sync.WaitGroup
+	var count int64
+	pid := spawnCounter(&wg, &count)
+	hub.Subscribe("block.*", pid)
+
+	wg.Add(1)
+	hub.Publish(&Event{Topic: "block.added", Message: "event"})
+	waitTimeout(t, &wg, time.Second)
+	assert.Equal(t, int64(1), count)
+	assert.Equal(t, Metrics{Published: 1, Sent: 1}, hub.Metrics())
+
+	hub.RemovePID(pid)
+	assert.True(t, hub.wildcards.Empty())
+}
+
+func TestEventHub_ConcurrentSubscribeUnsubscribe(t *testing.T) {
+	hub := newTestHub()
+	pids := make([]*actor.PID, 200)
+	for i := range pids {
+		pids[i] = actor.NewLocalPID("subscriber" + strconv.Itoa(i))
+	}
+
+	var wg sync.WaitGroup
+	for i := range pids {
+		wg.Add(1)
+		go func(pid *actor.PID, unsubscribe bool) {
+			defer wg.Done()
+			hub.Subscribe("topic", pid)
+			if unsubscribe {
+				hub.Unsubscribe("topic", pid)
+			}
+		}(pids[i], i%2 == 0)
+	}
+	wg.Wait()
+
+	assert.Len(t, hub.topic("topic", false).Load(), len(pids)/2)
+}
+
+func TestEventHub_RoundRobinConcurrentPublish(t *testing.T) {
+	const publishers, events, subscribers = 8, 1000, 4
+
+	hub := newTestHub()
+	var wg sync.WaitGroup
+	wg.Add(publishers * events)
+	counts := make([]int64, subscribers)
+	for i := range counts {
+		hub.Subscribe("topic", spawnCounter(&wg, &counts[i]))
+	}
+
+	for p := 0; p < publishers; p++ {
+		go func() {
+			for i := 0; i < events; i++ {
+				hub.Publish(&Event{Topic: "topic", Message: "event", Policy: PublishPolicyRoundRobin})
+			}
+		}()
+	}
+	waitTimeout(t, &wg, 10*time.Second)
+
+	for i := range counts {
+		assert.Equal(t, int64(publishers*events/subscribers), atomic.LoadInt64(&counts[i]))
+	}
+}
+
+func TestEventHub_PublishWhileSubscribing(t *testing.T) {
+	const publishers, events = 4, 1000
+
+	hub := newTestHub()
+	var wg sync.WaitGroup
+	var count int64
+	wg.Add(publishers * events)
+	hub.Subscribe("topic", spawnCounter(&wg, &count))
+
+	stop := make(chan struct{})
+	var churn sync.WaitGroup
+	for c := 0; c < 4; c++ {
+		churn.Add(1)
+		go func() {
+			defer churn.Done()
+			pid := spawnCounter(nil, nil)
+			defer pid.Stop()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					hub.Subscribe("topic", pid)
+					hub.Subscribe("other", pid)
+					hub.Subscribe("other.#", pid)
+					hub.Unsubscribe("topic", pid)
+					hub.Unsubscribe("other", pid)
+					hub.Unsubscribe("other.#", pid)
+				}
+			}
+		}()
+	}
+
+	for p := 0; p < publishers; p++ {
+		go func() {
+			for i := 0; i < events; i++ {
+				//only the fan out to "topic" is counted, the churning subscribers may take any other event
+				hub.Publish(&Event{Topic: "topic", Message: "event", Policy: PublishPolicyAll})
+				hub.Publish(&Event{Topic: "other", Message: 0, Policy: PublishPolicyRoundRobin})
+				hub.Publish(&Event{Topic: "other.sub", Message: 0, Policy: PublishPolicyRandom})
+			}
+		}()
+	}
+
+	waitTimeout(t, &wg, 10*time.Second)
+	close(stop)
+	churn.Wait()
+}
+
+func TestEventHub_UnsubscribeByValue(t *testing.T) {
+	hub := newTestHub()
+	hub.Subscribe("topic", actor.NewPID("remote:8080", "subscriber"))
+	hub.Subscribe("topic.#", actor.NewPID("remote:8080", "subscriber"))
+	hub.Subscribe("other", actor.NewPID("remote:8080", "subscriber"))
+
+	hub.Unsubscribe("topic", actor.NewPID("remote:8080", "subscriber"))
+	assert.Empty(t, hub.topic("topic", false).Load())
+
+	hub.RemovePID(actor.NewPID("remote:8080", "subscriber"))
+	assert.Empty(t, hub.topic("other", false).Load())
+	assert.True(t, hub.wildcards.Empty())
+}
+
+func TestEventHub_SubscriptionHandle(t *testing.T) {
+	hub := newTestHub()
+	pid := actor.NewLocalPID("subscriber")
+	first := hub.Subscribe("topic", pid)
+	second := hub.Subscribe("topic", pid)
+
+	first.Unsubscribe()
+	first.Unsubscribe()
+
+	subs := hub.topic("topic", false).Load()
+	if assert.Len(t, subs, 1) {
+		assert.Equal(t, second.sub, subs[0])
+	}
+	assert.Equal(t, "topic", second.Topic())
+	assert.Equal(t, pid, second.Subscriber())
+}
+
+func TestEventHub_RemoveAddress(t *testing.T) {
+	hub := newTestHub()
+	hub.Subscribe("topic", actor.NewPID("remote:8080", "a"))
+	hub.Subscribe("topic.*", actor.NewPID("remote:8080", "b"))
+	local := hub.Subscribe("topic", actor.NewLocalPID("c"))
+
+	hub.RemoveAddress("remote:8080")
+
+	assert.Equal(t, []*subscription{local.sub}, hub.topic("topic", false).Load())
+	assert.True(t, hub.wildcards.Empty())
+}
+
+func TestEventHub_RemovesTerminatedSubscribers(t *testing.T) {
+	hub := newTestHub()
+	hub.watchSubscribers = true
+
+	terminated := make(chan *SubscriberTerminatedEvent, 1)
+	sub := eventstream.Subscribe(func(evt interface{}) {
+		if e, ok := evt.(*SubscriberTerminatedEvent); ok && e.Hub == hub {
+			terminated <- e
+		}
+	})
+	defer eventstream.Unsubscribe(sub)
+
+	pid := actor.Spawn(actor.FromFunc(func(actor.Context) {}))
+	hub.Subscribe("topic", pid)
+	hub.Subscribe("topic.#", pid)
+	pid.GracefulStop()
+
+	select {
+	case e := <-terminated:
+		assert.Equal(t, pid, e.Subscriber)
+		assert.ElementsMatch(t, []string{"topic", "topic.#"}, e.Topics)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "subscriber was not removed")
+	}
+	assert.Empty(t, hub.topic("topic", false).Load())
+	assert.True(t, hub.wildcards.Empty())
+}
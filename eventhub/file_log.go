@@ -0,0 +1,387 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+	"github.com/gogo/protobuf/proto"
+)
+
+const segmentExt = ".log"
+
+var ErrNotSerializable = errors.New("eventhub: retained message must be proto.Message")
+
+// logRecord is an event as stored in a segment file, prefixed by its big endian uint32 length
+type logRecord struct {
+	Offset    uint64            `protobuf:"varint,1,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	Publisher *actor.PID        `protobuf:"bytes,2,opt,name=Publisher" json:"Publisher,omitempty"`
+	Topic     string            `protobuf:"bytes,3,opt,name=Topic,proto3" json:"Topic,omitempty"`
+	Policy    int32             `protobuf:"varint,4,opt,name=Policy,proto3" json:"Policy,omitempty"`
+	TypeName  string            `protobuf:"bytes,5,opt,name=TypeName,proto3" json:"TypeName,omitempty"`
+	Message   []byte            `protobuf:"bytes,6,opt,name=Message,proto3" json:"Message,omitempty"`
+	Header    map[string]string `protobuf:"bytes,7,rep,name=Header" json:"Header,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *logRecord) Reset()         { *m = logRecord{} }
+func (m *logRecord) String() string { return proto.CompactTextString(m) }
+func (*logRecord) ProtoMessage()    {}
+
+// fileLog is a RetentionLog appending the events to segment files, each named after the offset of its first event
+type fileLog struct {
+	sync.RWMutex
+	dir         string
+	segmentSize uint64
+	maxSegments int
+	segments    []uint64 // first offsets of the segments, oldest first
+	file        *os.File // the last segment, open for appending
+	size        int64    // bytes of the complete records in file
+	next        uint64
+	sync        bool
+}
+
+// FileLogOption configures a RetentionLog created with NewFileLog
+type FileLogOption func(*fileLog)
+
+// WithFileSync makes Append flush every event to stable storage before it is published, so that retained events
+// survive a crash of the machine and not only of the process, at the cost of a disk flush per event
+func WithFileSync(enabled bool) FileLogOption {
+	return func(l *fileLog) {
+		l.sync = enabled
+	}
+}
+
+// NewFileLog returns a RetentionLog which appends the events to segment files of segmentSize events in dir.
+// Once there are more than maxSegments segments the oldest is deleted, zero keeps all of them.
+//
+// Events already in dir are recovered, so offsets continue where the previous log stopped. Retained messages
+// must be proto.Message and their types registered, as for remote
+func NewFileLog(dir string, segmentSize, maxSegments int, options ...FileLogOption) (RetentionLog, error) {
+	if segmentSize < 1 {
+		segmentSize = 1
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	l := &fileLog{dir: dir, segmentSize: uint64(segmentSize), maxSegments: maxSegments, next: 1}
+	for _, option := range options {
+		option(l)
+	}
+	if err := l.recover(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *fileLog) path(segment uint64) string {
+	return filepath.Join(l.dir, fmt.Sprintf("%020d%s", segment, segmentExt))
+}
+
+// recover loads the segments of dir and truncates a partially written record at the end of the last one
+func (l *fileLog) recover() error {
+	infos, err := ioutil.ReadDir(l.dir)
+	if err != nil {
+		return err
+	}
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || !strings.HasSuffix(name, segmentExt) {
+			continue
+		}
+		segment, err := strconv.ParseUint(strings.TrimSuffix(name, segmentExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		l.segments = append(l.segments, segment)
+	}
+	if len(l.segments) == 0 {
+		return nil
+	}
+	sort.Slice(l.segments, func(i, j int) bool { return l.segments[i] < l.segments[j] })
+
+	last := l.segments[len(l.segments)-1]
+	l.next = last
+	end, err := readSegment(l.path(last), func(record *logRecord) bool {
+		l.next = record.Offset + 1
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(l.path(last), os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if err = file.Truncate(end); err == nil {
+		_, err = file.Seek(end, io.SeekStart)
+	}
+	if err != nil {
+		file.Close()
+		return err
+	}
+	l.file = file
+	l.size = end
+	return nil
+}
+
+func (l *fileLog) Append(event *Event) (*Event, error) {
+	message, ok := event.Message.(proto.Message)
+	if !ok {
+		return nil, ErrNotSerializable
+	}
+	data, err := proto.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	if l.file == nil || l.next-l.segments[len(l.segments)-1] >= l.segmentSize {
+		if err := l.rotate(); err != nil {
+			return nil, err
+		}
+	}
+	record, err := proto.Marshal(&logRecord{
+		Offset:    l.next,
+		Publisher: event.Publisher,
+		Topic:     event.Topic,
+		Policy:    int32(event.Policy),
+		TypeName:  proto.MessageName(message),
+		Message:   data,
+		Header:    event.Header,
+	})
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 4+len(record))
+	binary.BigEndian.PutUint32(buf, uint32(len(record)))
+	copy(buf[4:], record)
+	if _, err := l.file.Write(buf); err != nil {
+		l.discard()
+		return nil, err
+	}
+	if l.sync {
+		if err := l.file.Sync(); err != nil {
+			l.discard()
+			return nil, err
+		}
+	}
+	l.size += int64(len(buf))
+
+	retained := *event
+	retained.Offset = l.next
+	l.next++
+	return &retained, nil
+}
+
+// discard truncates a record which failed to be written, so that the next one is appended after the complete records
+func (l *fileLog) discard() {
+	if err := l.file.Truncate(l.size); err == nil {
+		l.file.Seek(l.size, io.SeekStart)
+	}
+}
+
+// rotate starts a new segment at the next offset and deletes the segments beyond maxSegments
+func (l *fileLog) rotate() error {
+	file, err := os.OpenFile(l.path(l.next), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if l.sync {
+		//the new segment must be found in the directory after a crash
+		if err := syncDir(l.dir); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	if l.file != nil {
+		l.file.Close()
+	}
+	l.file = file
+	l.size = 0
+	l.segments = append(l.segments, l.next)
+
+	for l.maxSegments > 0 && len(l.segments) > l.maxSegments {
+		if err := os.Remove(l.path(l.segments[0])); err != nil {
+			return err
+		}
+		l.segments = l.segments[1:]
+	}
+	return nil
+}
+
+func (l *fileLog) Bounds() (first, next uint64) {
+	l.RLock()
+	defer l.RUnlock()
+	return l.bounds()
+}
+
+func (l *fileLog) bounds() (first, next uint64) {
+	if len(l.segments) == 0 {
+		return l.next, l.next
+	}
+	return l.segments[0], l.next
+}
+
+func (l *fileLog) Read(from uint64, fn func(*Event) bool) error {
+	l.RLock()
+	defer l.RUnlock()
+
+	first, next := l.bounds()
+	if from < first || from > next {
+		return ErrOffsetOutOfRange
+	}
+	i := sort.Search(len(l.segments), func(i int) bool { return l.segments[i] > from }) - 1
+	if i < 0 {
+		return nil
+	}
+
+	var err error
+	done := false
+	for _, segment := range l.segments[i:] {
+		_, readErr := readSegment(l.path(segment), func(record *logRecord) bool {
+			if record.Offset < from {
+				return true
+			}
+			if record.Offset >= next {
+				done = true
+				return false
+			}
+			var event *Event
+			if event, err = record.event(); err != nil {
+				return false
+			}
+			done = !fn(event)
+			return !done
+		})
+		if readErr != nil {
+			return readErr
+		}
+		if err != nil || done {
+			break
+		}
+	}
+	return err
+}
+
+func (l *fileLog) Close() error {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+func (record *logRecord) event() (*Event, error) {
+	protoType := proto.MessageType(record.TypeName)
+	if protoType == nil {
+		return nil, fmt.Errorf("eventhub: unknown message type %v", record.TypeName)
+	}
+	message := reflect.New(protoType.Elem()).Interface().(proto.Message)
+	if err := proto.Unmarshal(record.Message, message); err != nil {
+		return nil, err
+	}
+	return &Event{
+		Publisher: record.Publisher,
+		Topic:     record.Topic,
+		Message:   message,
+		Policy:    PublishPolicy(record.Policy),
+		Offset:    record.Offset,
+		Header:    record.Header,
+	}, nil
+}
+
+// readSegment calls fn with the records of the segment file until fn returns false and returns the end of the
+// last complete record, a record cut short by a crash ends the segment
+func readSegment(path string, fn func(*logRecord) bool) (int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	var end int64
+	var size [4]byte
+	for {
+		if _, err := io.ReadFull(reader, size[:]); err != nil {
+			return end, truncated(err)
+		}
+		data := make([]byte, binary.BigEndian.Uint32(size[:]))
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return end, truncated(err)
+		}
+		record := &logRecord{}
+		if err := proto.Unmarshal(data, record); err != nil {
+			return end, nil
+		}
+		end += int64(len(size) + len(data))
+		if !fn(record) {
+			return end, nil
+		}
+	}
+}
+
+func syncDir(dir string) error {
+	file, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return file.Sync()
+}
+
+// truncated ignores the errors of reading a segment which ends early
+func truncated(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return nil
+	}
+	return err
+}
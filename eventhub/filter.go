@@ -0,0 +1,85 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+)
+
+// Predicate decides whether an event is delivered to a subscriber
+type Predicate func(event *Event) bool
+
+// SubscribeWithPredicate subscribes to the topic like Subscribe, but only the events accepted by the predicate
+// are delivered. The predicate is called concurrently by the publishers and must not block.
+//
+// In cluster mode a predicate cannot be evaluated by the publishing node, every event of the topic is forwarded
+// to the node of the subscriber and filtered there
+func (this *EventHub) SubscribeWithPredicate(topic string, subscriber *actor.PID, predicate Predicate) *SubscriptionHandle {
+	return this.subscribe(&subscription{topic: topic, pid: subscriber, filter: predicate})
+}
+
+// SubscribeWithSelector subscribes to the topic like Subscribe, but only the events whose Header matches the
+// selector expression are delivered, e.g. "type = 'block' AND height IN ('1', '2')". See selector for the grammar.
+//
+// In cluster mode the selector is evaluated by the publishing node, so events are forwarded only to the nodes
+// with matching subscribers
+func (this *EventHub) SubscribeWithSelector(topic string, subscriber *actor.PID, selector string) (*SubscriptionHandle, error) {
+	sel, err := parseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	return this.subscribe(&subscription{topic: topic, pid: subscriber, filter: headerFilter(sel), selector: selector}), nil
+}
+
+func headerFilter(sel selector) Predicate {
+	return func(event *Event) bool {
+		return sel(event.Header)
+	}
+}
+
+// without returns subs without the subscriptions accepted by drop, which is called once per subscription in order.
+// subs is only copied when a subscription is dropped, as it may be a shared snapshot
+func without(subs []*subscription, drop func(*subscription) bool) []*subscription {
+	for i, sub := range subs {
+		if !drop(sub) {
+			continue
+		}
+		result := append(make([]*subscription, 0, len(subs)-1), subs[:i]...)
+		for _, sub := range subs[i+1:] {
+			if !drop(sub) {
+				result = append(result, sub)
+			}
+		}
+		return result
+	}
+	return subs
+}
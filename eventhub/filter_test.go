@@ -0,0 +1,91 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSelector(t *testing.T) {
+	header := map[string]string{"type": "block", "height": "5", "quote": "it's"}
+	cases := map[string]bool{
+		"type = 'block'":                                    true,
+		"type != 'block'":                                   false,
+		"type = 'tx' OR height = '5'":                       true,
+		"type = 'block' AND height = '6'":                   false,
+		"height IN ('1', '5')":                              true,
+		"NOT height IN ('1', '5')":                          false,
+		"priority EXISTS":                                   false,
+		"not priority exists and type = 'block'":            true,
+		"(type = 'tx' OR height = '5') AND quote = 'it''s'": true,
+		"missing = ''":                                      true,
+	}
+	for expr, expected := range cases {
+		sel, err := parseSelector(expr)
+		if assert.NoError(t, err, expr) {
+			assert.Equal(t, expected, sel(header), expr)
+		}
+	}
+
+	for _, expr := range []string{"", "type", "type = block", "type = 'block' AND", "(type = 'a'", "type IN 'a'", "type = 'a"} {
+		_, err := parseSelector(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestEventHub_SubscribeWithFilter(t *testing.T) {
+	hub := newTestHub()
+	var wg sync.WaitGroup
+	var all, selected, predicated int64
+	hub.Subscribe("topic", spawnCounter(&wg, &all))
+	_, err := hub.SubscribeWithSelector("topic", spawnCounter(&wg, &selected), "kind = 'selected'")
+	assert.NoError(t, err)
+	hub.SubscribeWithPredicate("topic", spawnCounter(&wg, &predicated), func(event *Event) bool {
+		return event.Message == "predicated"
+	})
+	_, err = hub.SubscribeWithSelector("topic", spawnCounter(nil, nil), "kind =")
+	assert.Error(t, err)
+
+	wg.Add(5)
+	hub.Publish(&Event{Topic: "topic", Message: "plain"})
+	hub.Publish(&Event{Topic: "topic", Message: "plain", Header: map[string]string{"kind": "selected"}})
+	hub.Publish(&Event{Topic: "topic", Message: "predicated"})
+	waitTimeout(t, &wg, time.Second)
+
+	assert.Equal(t, int64(3), all)
+	assert.Equal(t, int64(1), selected)
+	assert.Equal(t, int64(1), predicated)
+}
@@ -0,0 +1,242 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"errors"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+	"github.com/OnyxPay/OnyxChain-eventbus/log"
+)
+
+// DeadLetterTopic is the default topic of the events no consumer group member acknowledged
+const DeadLetterTopic = "eventhub.deadletter"
+
+var ErrGroupExists = errors.New("eventhub: consumer group exists")
+
+// GroupConfig configures the delivery of a consumer group
+type GroupConfig struct {
+	// AckTimeout is the time a member has to respond to an event before it is redelivered
+	AckTimeout time.Duration
+	// MaxDeliveries is the number of failed deliveries after which an event goes to DeadLetterTopic
+	MaxDeliveries   int
+	DeadLetterTopic string
+}
+
+var DefaultGroupConfig = GroupConfig{
+	AckTimeout:      5 * time.Second,
+	MaxDeliveries:   3,
+	DeadLetterTopic: DeadLetterTopic,
+}
+
+// DeadLetterEvent is published to the dead letter topic of a consumer group for an event no member acknowledged
+type DeadLetterEvent struct {
+	Topic      string
+	Group      string
+	Message    interface{}
+	Publisher  *actor.PID
+	Deliveries int
+}
+
+type groupKey struct {
+	topic string
+	group string
+}
+
+type groupJoin struct {
+	member *actor.PID
+}
+
+type groupLeave struct {
+	member *actor.PID
+}
+
+type groupDelivery struct {
+	message    interface{}
+	header     map[string]string
+	publisher  *actor.PID
+	deliveries int
+}
+
+// consumerGroup subscribes to a topic on behalf of its members and delivers every event to exactly one of them
+type consumerGroup struct {
+	hub     *EventHub
+	key     groupKey
+	config  GroupConfig
+	members []*actor.PID
+	next    int
+	// pending holds the events received while the group had no members
+	pending []*groupDelivery
+}
+
+// ConfigureGroup creates the consumer group of the topic with config, it fails if the group exists already
+func (this *EventHub) ConfigureGroup(topic, group string, config GroupConfig) error {
+	_, created := this.consumerGroup(groupKey{topic: topic, group: group}, config)
+	if !created {
+		return ErrGroupExists
+	}
+	return nil
+}
+
+// SubscribeGroup adds the member to the consumer group of the topic, creating the group with DefaultGroupConfig
+// unless it was configured with ConfigureGroup.
+//
+// Each event of the topic is delivered to one member of the group, which must respond to it within the ack timeout,
+// any response acknowledges the event. Otherwise the event is delivered to the next member, and once it failed
+// MaxDeliveries times it is published to the dead letter topic as a *DeadLetterEvent. The sender of the delivered
+// message is the future awaiting the response, not the publisher
+func (this *EventHub) SubscribeGroup(topic, group string, member *actor.PID) {
+	pid, _ := this.consumerGroup(groupKey{topic: topic, group: group}, DefaultGroupConfig)
+	pid.Tell(&groupJoin{member: member})
+}
+
+// UnsubscribeGroup removes the member from the consumer group of the topic, events awaiting its response are
+// redelivered once their ack timeout expired
+func (this *EventHub) UnsubscribeGroup(topic, group string, member *actor.PID) {
+	this.groupsMu.Lock()
+	pid, ok := this.groups[groupKey{topic: topic, group: group}]
+	this.groupsMu.Unlock()
+	if ok {
+		pid.Tell(&groupLeave{member: member})
+	}
+}
+
+func (this *EventHub) consumerGroup(key groupKey, config GroupConfig) (*actor.PID, bool) {
+	this.groupsMu.Lock()
+	defer this.groupsMu.Unlock()
+
+	if pid, ok := this.groups[key]; ok {
+		return pid, false
+	}
+	if this.groups == nil {
+		this.groups = make(map[groupKey]*actor.PID)
+	}
+	props := actor.FromProducer(func() actor.Actor {
+		return &consumerGroup{hub: this, key: key, config: config}
+	})
+	pid := this.system().Spawn(props)
+	this.groups[key] = pid
+	this.Subscribe(key.topic, pid)
+	return pid, true
+}
+
+func (state *consumerGroup) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *groupJoin:
+		for _, member := range state.members {
+			if pidKey(member) == pidKey(msg.member) {
+				return
+			}
+		}
+		state.members = append(state.members, msg.member)
+		ctx.Watch(msg.member)
+
+		pending := state.pending
+		state.pending = nil
+		for _, delivery := range pending {
+			state.deliver(ctx, delivery)
+		}
+	case *groupLeave:
+		if state.remove(msg.member) {
+			ctx.Unwatch(msg.member)
+		}
+	case *actor.Terminated:
+		state.remove(msg.Who)
+	case actor.SystemMessage, actor.AutoReceiveMessage:
+		//ignore
+	default:
+		state.deliver(ctx, &groupDelivery{
+			message:   msg,
+			header:    ctx.MessageHeader().ToMap(),
+			publisher: ctx.Sender(),
+		})
+	}
+}
+
+func (state *consumerGroup) remove(pid *actor.PID) bool {
+	for i, member := range state.members {
+		if pidKey(member) == pidKey(pid) {
+			state.members = append(state.members[:i], state.members[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// deliver sends the event to the next member and redelivers it if no response arrived within the ack timeout
+func (state *consumerGroup) deliver(ctx actor.Context, delivery *groupDelivery) {
+	if len(state.members) == 0 {
+		state.pending = append(state.pending, delivery)
+		return
+	}
+	member := state.members[state.next%len(state.members)]
+	state.next++
+	delivery.deliveries++
+
+	future := state.hub.system().NewFuture(state.config.AckTimeout)
+	env := &actor.MessageEnvelope{
+		Message: delivery.message,
+		Sender:  future.PID(),
+	}
+	for key, value := range delivery.header {
+		env.SetHeader(key, value)
+	}
+	member.Tell(env)
+
+	ctx.AwaitFuture(future, func(res interface{}, err error) {
+		if err == nil {
+			return
+		}
+		if delivery.deliveries < state.config.MaxDeliveries {
+			state.deliver(ctx, delivery)
+			return
+		}
+		plog.Info("event not acknowledged by consumer group", log.String("topic", state.key.topic),
+			log.String("group", state.key.group), log.Int("deliveries", delivery.deliveries))
+		err = state.hub.Publish(&Event{
+			Publisher: delivery.publisher,
+			Topic:     state.config.DeadLetterTopic,
+			Message: &DeadLetterEvent{
+				Topic:      state.key.topic,
+				Group:      state.key.group,
+				Message:    delivery.message,
+				Publisher:  delivery.publisher,
+				Deliveries: delivery.deliveries,
+			},
+			Policy: PublishPolicyAll,
+		})
+		if err != nil {
+			plog.Error("failed to publish dead letter", log.String("topic", state.config.DeadLetterTopic), log.Error(err))
+		}
+	})
+}
@@ -0,0 +1,113 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+func spawnMember(received chan string, name string, ack bool) *actor.PID {
+	return actor.Spawn(actor.FromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(string); ok {
+			received <- name + ":" + msg
+			if ack {
+				ctx.Respond(true)
+			}
+		}
+	}))
+}
+
+func TestEventHub_ConsumerGroupRedelivers(t *testing.T) {
+	hub := newTestHub()
+	config := GroupConfig{AckTimeout: 50 * time.Millisecond, MaxDeliveries: 3, DeadLetterTopic: "dead"}
+	assert.NoError(t, hub.ConfigureGroup("topic", "group", config))
+	assert.Equal(t, ErrGroupExists, hub.ConfigureGroup("topic", "group", config))
+
+	received := make(chan string, 10)
+	silent := spawnMember(received, "silent", false)
+	defer silent.Stop()
+	acking := spawnMember(received, "acking", true)
+	defer acking.Stop()
+	hub.SubscribeGroup("topic", "group", silent)
+	hub.SubscribeGroup("topic", "group", acking)
+
+	hub.Publish(&Event{Topic: "topic", Message: "event", Policy: PublishPolicyAll})
+	for _, expected := range []string{"silent:event", "acking:event"} {
+		select {
+		case msg := <-received:
+			assert.Equal(t, expected, msg)
+		case <-time.After(time.Second):
+			assert.Fail(t, "timed out waiting for "+expected)
+			return
+		}
+	}
+	select {
+	case msg := <-received:
+		assert.Fail(t, "unexpected redelivery "+msg)
+	case <-time.After(150 * time.Millisecond):
+	}
+}
+
+func TestEventHub_ConsumerGroupDeadLetter(t *testing.T) {
+	hub := newTestHub()
+	assert.NoError(t, hub.ConfigureGroup("topic", "group", GroupConfig{AckTimeout: 20 * time.Millisecond, MaxDeliveries: 2, DeadLetterTopic: "dead"}))
+
+	deadLetters := make(chan *DeadLetterEvent, 1)
+	probe := actor.Spawn(actor.FromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(*DeadLetterEvent); ok {
+			deadLetters <- msg
+		}
+	}))
+	defer probe.Stop()
+	hub.Subscribe("dead", probe)
+
+	received := make(chan string, 10)
+	silent := spawnMember(received, "silent", false)
+	defer silent.Stop()
+	hub.Publish(&Event{Topic: "topic", Message: "event", Policy: PublishPolicyAll})
+	hub.SubscribeGroup("topic", "group", silent)
+
+	select {
+	case dead := <-deadLetters:
+		assert.Equal(t, "topic", dead.Topic)
+		assert.Equal(t, "group", dead.Group)
+		assert.Equal(t, "event", dead.Message)
+		assert.Equal(t, 2, dead.Deliveries)
+	case <-time.After(time.Second):
+		assert.Fail(t, "timed out waiting for the dead letter")
+	}
+	assert.Len(t, received, 2)
+}
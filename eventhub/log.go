@@ -29,11 +29,20 @@ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
 See the License for the specific language governing permissions and
 limitations under the License.
 *****************************************************/
-package zmqremote
 
-import "github.com/OnyxPay/OnyxChain-eventbus/actor"
+package eventhub
 
-func remoteHandler(pid *actor.PID) (actor.Process, bool) {
-	ref := newProcess(pid)
-	return ref, true
+import (
+	"github.com/OnyxPay/OnyxChain-eventbus/log"
+)
+
+var (
+	plog = log.New(log.DebugLevel, "[EVENTHUB]")
+)
+
+// SetLogLevel sets the log level for the logger.
+//
+// SetLogLevel is safe to call concurrently
+func SetLogLevel(level log.Level) {
+	plog.SetLevel(level)
 }
@@ -0,0 +1,185 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"sync/atomic"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+	"github.com/OnyxPay/OnyxChain-eventbus/eventstream"
+)
+
+// Option configures an EventHub created with New
+type Option func(*hubConfig)
+
+type hubConfig struct {
+	defaultPolicy    PublishPolicy
+	wildcards        bool
+	metrics          bool
+	watchSubscribers bool
+	eventStream      *eventstream.EventStream
+	system           *actor.ActorSystem
+	clusterName      string
+}
+
+func defaultHubConfig() *hubConfig {
+	return &hubConfig{
+		defaultPolicy:    PublishPolicyAll,
+		wildcards:        true,
+		metrics:          true,
+		watchSubscribers: true,
+		system:           actor.DefaultSystem(),
+		clusterName:      ClusterPeerName,
+	}
+}
+
+// WithDefaultPolicy sets the policy of the events published with PublishPolicyDefault
+func WithDefaultPolicy(policy PublishPolicy) Option {
+	return func(config *hubConfig) {
+		if policy != PublishPolicyDefault {
+			config.defaultPolicy = policy
+		}
+	}
+}
+
+// WithWildcards enables the wildcard topic patterns, when disabled topics containing wildcards are plain topics
+func WithWildcards(enabled bool) Option {
+	return func(config *hubConfig) {
+		config.wildcards = enabled
+	}
+}
+
+// WithMetrics enables the counters returned by Metrics and the OverflowEvents published on the EventStream
+func WithMetrics(enabled bool) Option {
+	return func(config *hubConfig) {
+		config.metrics = enabled
+	}
+}
+
+// WithSubscriberWatch makes the hub watch its local subscribers and remove them once they terminated
+func WithSubscriberWatch(enabled bool) Option {
+	return func(config *hubConfig) {
+		config.watchSubscribers = enabled
+	}
+}
+
+// WithEventStream sets the EventStream the hub publishes its events on, it defaults to the one of the actor system
+func WithEventStream(stream *eventstream.EventStream) Option {
+	return func(config *hubConfig) {
+		config.eventStream = stream
+	}
+}
+
+// WithSystem sets the ActorSystem the hub spawns its actors in, it defaults to the default system
+func WithSystem(system *actor.ActorSystem) Option {
+	return func(config *hubConfig) {
+		if system != nil {
+			config.system = system
+		}
+	}
+}
+
+// WithClusterName sets the name of the cluster peer of the hub, it defaults to ClusterPeerName.
+// The hubs of a cluster have the same name, a node runs the hubs of several clusters under different names
+func WithClusterName(name string) Option {
+	return func(config *hubConfig) {
+		if name != "" {
+			config.clusterName = name
+		}
+	}
+}
+
+// events returns the EventStream the hub publishes its events on
+func (this *EventHub) events() *eventstream.EventStream {
+	this.init()
+	if this.config.eventStream != nil {
+		return this.config.eventStream
+	}
+	return this.config.system.EventStream()
+}
+
+// system returns the ActorSystem of the hub actors
+func (this *EventHub) system() *actor.ActorSystem {
+	this.init()
+	return this.config.system
+}
+
+func (this *EventHub) clusterName() string {
+	this.init()
+	return this.config.clusterName
+}
+
+// isPattern reports whether the topic is a wildcard pattern for the hub
+func (this *EventHub) isPattern(topic string) bool {
+	this.init()
+	return this.config.wildcards && IsWildcardTopic(topic)
+}
+
+// Metrics are the counters of an EventHub since it was created
+type Metrics struct {
+	Published uint64 // events published, including the events forwarded by other nodes
+	Sent      uint64 // events sent to local subscribers
+	Forwarded uint64 // events forwarded to remote nodes
+	Dropped   uint64 // events dropped by overflow policies
+}
+
+type hubMetrics struct {
+	published uint64
+	sent      uint64
+	forwarded uint64
+	dropped   uint64
+}
+
+// Metrics returns the counters of the hub, they are all zero when the metrics are disabled
+func (this *EventHub) Metrics() Metrics {
+	this.init()
+	if this.metrics == nil {
+		return Metrics{}
+	}
+	return Metrics{
+		Published: atomic.LoadUint64(&this.metrics.published),
+		Sent:      atomic.LoadUint64(&this.metrics.sent),
+		Forwarded: atomic.LoadUint64(&this.metrics.forwarded),
+		Dropped:   atomic.LoadUint64(&this.metrics.dropped),
+	}
+}
+
+// overflowed records a triggered overflow policy
+func (this *EventHub) overflowed(overflow *OverflowEvent) {
+	if this.metrics == nil {
+		return
+	}
+	if overflow.Dropped {
+		atomic.AddUint64(&this.metrics.dropped, 1)
+	}
+	this.events().Publish(overflow)
+}
@@ -0,0 +1,112 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_Options(t *testing.T) {
+	hub := New(WithDefaultPolicy(PublishPolicyRoundRobin), WithWildcards(false), WithSubscriberWatch(false))
+	other := New(WithMetrics(false), WithSubscriberWatch(false))
+
+	var wg sync.WaitGroup
+	var first, second, literal int64
+	hub.Subscribe("topic", spawnCounter(&wg, &first))
+	hub.Subscribe("topic", spawnCounter(&wg, &second))
+	hub.Subscribe("topic.*", spawnCounter(&wg, &literal))
+	other.Subscribe("topic", spawnCounter(nil, nil))
+
+	wg.Add(5)
+	for i := 0; i < 4; i++ {
+		event := &Event{Topic: "topic", Message: "event", Policy: PublishPolicyDefault}
+		hub.Publish(event)
+		assert.Equal(t, PublishPolicy(PublishPolicyDefault), event.Policy)
+	}
+	hub.Publish(&Event{Topic: "topic.*", Message: "event"})
+	other.Publish(&Event{Topic: "topic", Message: "event"})
+	waitTimeout(t, &wg, time.Second)
+
+	assert.Equal(t, int64(2), first)
+	assert.Equal(t, int64(2), second)
+	assert.Equal(t, int64(1), literal)
+	assert.Equal(t, Metrics{Published: 5, Sent: 5}, hub.Metrics())
+	assert.Equal(t, Metrics{}, other.Metrics())
+}
+
+func TestNew_WithSystem(t *testing.T) {
+	system := actor.NewSystem(actor.Config{})
+	defer system.Shutdown()
+	hub := New(WithSystem(system))
+	address := system.ProcessRegistry.Address
+
+	topic, err := NewTopic[string](hub, "topic")
+	assert.NoError(t, err)
+	received := make(chan string, 1)
+	handle, err := topic.SubscribeFunc(func(msg string) {
+		received <- msg
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, address, handle.Subscriber().Address)
+	topic.Publish("event")
+	select {
+	case msg := <-received:
+		assert.Equal(t, "event", msg)
+	case <-time.After(time.Second):
+		assert.Fail(t, "timed out waiting for the event")
+	}
+
+	assert.NoError(t, hub.ConfigureGroup("group", "group", GroupConfig{AckTimeout: time.Second}))
+	assert.Equal(t, address, hub.groups[groupKey{topic: "group", group: "group"}].Address)
+
+	terminated := make(chan *SubscriberTerminatedEvent, 1)
+	sub := system.EventStream().SubscribeType((*SubscriberTerminatedEvent)(nil), func(evt interface{}) {
+		terminated <- evt.(*SubscriberTerminatedEvent)
+	})
+	defer system.EventStream().Unsubscribe(sub)
+	handle.Unsubscribe()
+	subscriber := system.Spawn(actor.FromFunc(func(ctx actor.Context) {}))
+	hub.Subscribe("topic", subscriber)
+	subscriber.Stop()
+	select {
+	case evt := <-terminated:
+		assert.Equal(t, subscriber, evt.Subscriber)
+		assert.Equal(t, address, hub.subscriberWatcher().Address)
+	case <-time.After(time.Second):
+		assert.Fail(t, "timed out waiting for the terminated subscriber")
+	}
+}
@@ -0,0 +1,176 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+	"github.com/OnyxPay/OnyxChain-eventbus/log"
+	"github.com/OnyxPay/OnyxChain-eventbus/router"
+	"github.com/serialx/hashring"
+)
+
+// MailboxLoad is a mailbox.Statistics counting the user and system messages waiting in a mailbox.
+//
+// Attach a separate MailboxLoad to the mailbox of every subscriber, e.g.
+//
+//	load := eventhub.NewMailboxLoad()
+//	pid := actor.Spawn(props.WithMailbox(mailbox.Unbounded(load)))
+//	hub.SubscribeWithLoad("topic", pid, load)
+type MailboxLoad struct {
+	backlog int64
+	mu      sync.Mutex
+	// received is closed when a message was processed, for the publishers waiting for space
+	received chan struct{}
+}
+
+func NewMailboxLoad() *MailboxLoad {
+	return &MailboxLoad{}
+}
+
+// Backlog returns the number of messages posted to the mailbox and not yet processed
+func (l *MailboxLoad) Backlog() int64 {
+	return atomic.LoadInt64(&l.backlog)
+}
+
+func (l *MailboxLoad) MailboxStarted() {}
+
+func (l *MailboxLoad) MessagePosted(message interface{}) {
+	atomic.AddInt64(&l.backlog, 1)
+}
+
+func (l *MailboxLoad) MessageReceived(message interface{}) {
+	atomic.AddInt64(&l.backlog, -1)
+	l.mu.Lock()
+	if l.received != nil {
+		close(l.received)
+		l.received = nil
+	}
+	l.mu.Unlock()
+}
+
+// changed returns a channel closed once the next message was processed
+func (l *MailboxLoad) changed() <-chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.received == nil {
+		l.received = make(chan struct{})
+	}
+	return l.received
+}
+
+func (l *MailboxLoad) MailboxEmpty() {}
+
+// SubscribeWithLoad subscribes to the topic like Subscribe and reports the mailbox backlog of the subscriber
+// to PublishPolicyLeastLoaded, subscribers without a load are considered idle
+func (this *EventHub) SubscribeWithLoad(topic string, subscriber *actor.PID, load *MailboxLoad) *SubscriptionHandle {
+	return this.subscribe(&subscription{topic: topic, pid: subscriber, load: load})
+}
+
+// policyState is the state of the publish policies for the subscriptions of a topic or of a wildcard pattern
+type policyState struct {
+	// round robin position, only accessed atomically
+	next uint64
+	// ring caches the *subscriptionRing of PublishPolicyConsistentHash
+	ring atomic.Value
+}
+
+// leastLoaded returns the index of the subscription with the smallest backlog, ties are broken round robin
+func (ps *policyState) leastLoaded(subs []*subscription) int {
+	start := atomic.AddUint64(&ps.next, 1) - 1
+	best := -1
+	var min int64
+	for n := range subs {
+		i := int((start + uint64(n)) % uint64(len(subs)))
+		var backlog int64
+		if subs[i].load != nil {
+			backlog = subs[i].load.Backlog()
+		}
+		if best < 0 || backlog < min {
+			best, min = i, backlog
+		}
+	}
+	return best
+}
+
+// subscriptionRing is the hash ring of a list of subscriptions
+type subscriptionRing struct {
+	subs  []*subscription
+	ring  *hashring.HashRing
+	nodes map[string]int
+}
+
+// hashed returns the index of the subscription of the router.Hasher key of the event message, or -1 if the message
+// does not implement router.Hasher
+func (ps *policyState) hashed(subs []*subscription, event *Event) int {
+	hasher, ok := event.Message.(router.Hasher)
+	if !ok {
+		plog.Error("message must implement router.Hasher", log.String("topic", event.Topic), log.TypeOf("type", event.Message))
+		return -1
+	}
+
+	//the ring is rebuilt only when the subscriptions changed
+	r, _ := ps.ring.Load().(*subscriptionRing)
+	if r == nil || !sameSubscriptions(r.subs, subs) {
+		r = &subscriptionRing{subs: subs, nodes: make(map[string]int, len(subs))}
+		names := make([]string, 0, len(subs))
+		for i, sub := range subs {
+			//a subscriber subscribed more than once is a single node
+			if _, ok := r.nodes[sub.key]; !ok {
+				r.nodes[sub.key] = i
+				names = append(names, sub.key)
+			}
+		}
+		r.ring = hashring.New(names)
+		ps.ring.Store(r)
+	}
+
+	node, ok := r.ring.GetNode(hasher.Hash())
+	if !ok {
+		return -1
+	}
+	return r.nodes[node]
+}
+
+func sameSubscriptions(a, b []*subscription) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
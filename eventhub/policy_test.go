@@ -0,0 +1,164 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+	"github.com/OnyxPay/OnyxChain-eventbus/mailbox"
+	"github.com/stretchr/testify/assert"
+)
+
+type accountEvent string
+
+func (e accountEvent) Hash() string {
+	return string(e)
+}
+
+// receiverProcess reports the id of the PID every message was sent to
+type receiverProcess struct {
+	received chan string
+}
+
+func (p *receiverProcess) SendUserMessage(pid *actor.PID, message interface{}) {
+	p.received <- pid.Id
+}
+
+func (p *receiverProcess) SendSystemMessage(pid *actor.PID, message interface{}) {}
+
+func (p *receiverProcess) Stop(pid *actor.PID) {}
+
+func receive(t *testing.T, received chan string) string {
+	select {
+	case id := <-received:
+		return id
+	case <-time.After(time.Second):
+		assert.Fail(t, "timed out waiting for a delivery")
+		return ""
+	}
+}
+
+func TestEventHub_PublishConsistentHash(t *testing.T) {
+	hub := newTestHub()
+	received := make(chan string, 10)
+	for i := 0; i < 5; i++ {
+		pid, _ := actor.ProcessRegistry.Add(&receiverProcess{received: received}, "receiver"+actor.ProcessRegistry.NextId())
+		hub.Subscribe("account", pid)
+	}
+
+	routes := make(map[string]string)
+	for round := 0; round < 3; round++ {
+		for _, account := range []string{"alice", "bob", "carol", "dave"} {
+			hub.Publish(&Event{Topic: "account", Message: accountEvent(account), Policy: PublishPolicyConsistentHash})
+			id := receive(t, received)
+			if round == 0 {
+				routes[account] = id
+			}
+			assert.Equal(t, routes[account], id, account)
+		}
+	}
+
+	//messages without a key are not delivered
+	hub.Publish(&Event{Topic: "account", Message: "no key", Policy: PublishPolicyConsistentHash})
+	assert.Len(t, received, 0)
+}
+
+func TestEventHub_PublishLeastLoaded(t *testing.T) {
+	hub := newTestHub()
+	received := make(chan string, 10)
+	loads := make([]*MailboxLoad, 3)
+	pids := make([]*actor.PID, 3)
+	for i := range loads {
+		loads[i] = NewMailboxLoad()
+		pids[i], _ = actor.ProcessRegistry.Add(&receiverProcess{received: received}, "loaded"+actor.ProcessRegistry.NextId())
+		hub.SubscribeWithLoad("work", pids[i], loads[i])
+	}
+	loads[0].MessagePosted(nil)
+	loads[0].MessagePosted(nil)
+	loads[1].MessagePosted(nil)
+	loads[2].MessagePosted(nil)
+	loads[2].MessagePosted(nil)
+
+	for i := 0; i < 3; i++ {
+		hub.Publish(&Event{Topic: "work", Message: "job", Policy: PublishPolicyLeastLoaded})
+		assert.Equal(t, pids[1].Id, receive(t, received))
+	}
+	loads[1].MessagePosted(nil)
+	loads[1].MessagePosted(nil)
+	loads[0].MessageReceived(nil)
+	hub.Publish(&Event{Topic: "work", Message: "job", Policy: PublishPolicyLeastLoaded})
+	assert.Equal(t, pids[0].Id, receive(t, received))
+}
+
+func TestEventHub_WildcardPolicyKeepsNoTopicState(t *testing.T) {
+	hub := newTestHub()
+	received := make(chan string, 10)
+	pids := make([]*actor.PID, 2)
+	for i := range pids {
+		pids[i], _ = actor.ProcessRegistry.Add(&receiverProcess{received: received}, "wildcard"+actor.ProcessRegistry.NextId())
+		hub.Subscribe("block.#", pids[i])
+	}
+
+	var previous string
+	for i := 0; i < 10000; i++ {
+		hub.Publish(&Event{Topic: "block.committed." + strconv.Itoa(i), Message: "block", Policy: PublishPolicyRoundRobin})
+		id := receive(t, received)
+		assert.NotEqual(t, previous, id)
+		previous = id
+	}
+	assert.Equal(t, 0, hub.subscribers.Count())
+}
+
+func TestMailboxLoad(t *testing.T) {
+	load := NewMailboxLoad()
+	blocked := make(chan struct{})
+	props := actor.FromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(string); ok {
+			<-blocked
+		}
+	}).WithMailbox(mailbox.Unbounded(load))
+	pid := actor.Spawn(props)
+	defer pid.Stop()
+
+	for i := 0; i < 3; i++ {
+		pid.Tell("work")
+	}
+	time.Sleep(50 * time.Millisecond)
+	//the first message is still being processed
+	assert.Equal(t, int64(3), load.Backlog())
+	close(blocked)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int64(0), load.Backlog())
+}
@@ -0,0 +1,110 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"errors"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+)
+
+// ErrNoReply is the error of the subscribers which had not replied when a PublishRequestFirst completed
+var ErrNoReply = errors.New("eventhub: request completed before the subscriber replied")
+
+// Reply is the response of a single subscriber to a published request
+type Reply struct {
+	Subscriber *actor.PID
+	Message    interface{}
+	// Err is actor.ErrTimeout if the subscriber did not respond in time
+	Err error
+}
+
+// PublishRequest sends the event like Publish, with a future as the sender of every delivered message, and waits
+// until all selected subscribers responded or the timeout expired. It returns a reply per subscriber, in the order
+// the event was sent to them.
+//
+// A remote node is a single subscriber in cluster mode, the first response of its subscribers is its reply.
+// The event is not sent when Publish would fail, PublishRequest returns the error
+func (this *EventHub) PublishRequest(event *Event, timeout time.Duration) ([]*Reply, error) {
+	return this.request(event, 0, timeout)
+}
+
+// PublishRequestFirst is like PublishRequest, but returns as soon as n subscribers responded
+// successfully. The replies of the others are ErrNoReply
+func (this *EventHub) PublishRequestFirst(event *Event, n int, timeout time.Duration) ([]*Reply, error) {
+	return this.request(event, n, timeout)
+}
+
+type reply struct {
+	i   int
+	res interface{}
+	err error
+}
+
+func (this *EventHub) request(event *Event, first int, timeout time.Duration) ([]*Reply, error) {
+	var replies []*Reply
+	var futures []*actor.Future
+	err := this.dispatch(event, false, func(sub *subscription, event *Event) {
+		future := this.system().NewFuture(timeout)
+		req := *event
+		req.Publisher = future.PID()
+		this.send(sub, &req)
+
+		replies = append(replies, &Reply{Subscriber: sub.pid, Err: ErrNoReply})
+		futures = append(futures, future)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(chan reply, len(futures))
+	for i, future := range futures {
+		go func(i int, future *actor.Future) {
+			res, err := future.Result()
+			results <- reply{i: i, res: res, err: err}
+		}(i, future)
+	}
+
+	succeeded := 0
+	for range futures {
+		r := <-results
+		replies[r.i].Message, replies[r.i].Err = r.res, r.err
+		if r.err == nil {
+			succeeded++
+			if succeeded == first {
+				break
+			}
+		}
+	}
+	return replies, nil
+}
@@ -0,0 +1,103 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+func spawnResponder(delay time.Duration) *actor.PID {
+	return actor.Spawn(actor.FromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(string); ok {
+			time.Sleep(delay)
+			ctx.Respond(msg + " reply")
+		}
+	}))
+}
+
+func TestEventHub_PublishRequest(t *testing.T) {
+	hub := newTestHub()
+	fast := spawnResponder(0)
+	defer fast.Stop()
+	slow := spawnResponder(time.Second)
+	defer slow.Stop()
+	hub.Subscribe("query", fast)
+	hub.Subscribe("query", slow)
+
+	replies, err := hub.PublishRequest(&Event{Topic: "query", Message: "query", Policy: PublishPolicyAll}, 100*time.Millisecond)
+	assert.NoError(t, err)
+	if assert.Len(t, replies, 2) {
+		assert.Equal(t, fast, replies[0].Subscriber)
+		assert.Equal(t, "query reply", replies[0].Message)
+		assert.NoError(t, replies[0].Err)
+		assert.Equal(t, slow, replies[1].Subscriber)
+		assert.Equal(t, actor.ErrTimeout, replies[1].Err)
+	}
+
+	start := time.Now()
+	replies, err = hub.PublishRequestFirst(&Event{Topic: "query", Message: "query", Policy: PublishPolicyAll}, 1, 2*time.Second)
+	assert.NoError(t, err)
+	assert.True(t, time.Since(start) < time.Second)
+	if assert.Len(t, replies, 2) {
+		assert.Equal(t, "query reply", replies[0].Message)
+		assert.Equal(t, ErrNoReply, replies[1].Err)
+	}
+
+	replies, err = hub.PublishRequest(&Event{Topic: "none", Message: "query"}, time.Second)
+	assert.NoError(t, err)
+	assert.Empty(t, replies)
+}
+
+func TestEventHub_PublishRequestRetentionError(t *testing.T) {
+	hub := newTestHub()
+	responder := spawnResponder(0)
+	defer responder.Stop()
+	hub.Subscribe("query", responder)
+	dir, err := ioutil.TempDir("", "eventhub")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	log, err := NewFileLog(dir, 2, 0)
+	assert.NoError(t, err)
+	defer log.Close()
+	assert.NoError(t, hub.Retain("query", log))
+
+	//a string is not a proto.Message, the request is neither retained nor sent
+	replies, err := hub.PublishRequest(&Event{Topic: "query", Message: "query"}, time.Second)
+	assert.Equal(t, ErrNotSerializable, err)
+	assert.Nil(t, replies)
+}
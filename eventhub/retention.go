@@ -0,0 +1,199 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"errors"
+	"math"
+	"sync"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+)
+
+// Start positions of SubscribeFrom, any other value is the offset of the first event to replay
+const (
+	OffsetEarliest uint64 = 0
+	OffsetLatest   uint64 = math.MaxUint64
+)
+
+// OffsetHeader is the message header holding the offset of an event delivered from a retained topic
+const OffsetHeader = "eventhub-offset"
+
+var (
+	ErrTopicNotRetained = errors.New("eventhub: topic not retained")
+	ErrTopicRetained    = errors.New("eventhub: topic already retained")
+	ErrRetainWildcard   = errors.New("eventhub: wildcard topics cannot be retained")
+	ErrOffsetOutOfRange = errors.New("eventhub: offset out of range")
+)
+
+// RetentionLog stores the events published to a retained topic.
+//
+// Offsets start at 1 and grow by one with every appended event. A log may drop its oldest events,
+// but the retained events always form a contiguous range of offsets.
+type RetentionLog interface {
+	// Append stores a copy of the event stamped with the next offset and returns the copy,
+	// the event itself is left unchanged as it may be published again
+	Append(event *Event) (*Event, error)
+	// Bounds returns the oldest retained offset and the offset the next event will get,
+	// both are equal when nothing is retained
+	Bounds() (first, next uint64)
+	// Read calls fn with the retained events from offset on, in order, until fn returns false
+	Read(from uint64, fn func(*Event) bool) error
+	Close() error
+}
+
+// Retain makes the topic durable, events published to it are stamped with an offset and kept in log so that
+// subscribers joining later can replay them with SubscribeFrom.
+//
+// Only subscriptions to the exact topic are replayed, subscribers of matching wildcard patterns get the new events only
+func (this *EventHub) Retain(topic string, log RetentionLog) error {
+	if this.isPattern(topic) {
+		return ErrRetainWildcard
+	}
+	ts := this.topic(topic, true)
+	ts.durable.Lock()
+	defer ts.durable.Unlock()
+
+	if ts.retention() != nil {
+		return ErrTopicRetained
+	}
+	ts.log.Store(log)
+	return nil
+}
+
+// SubscribeFrom subscribes to a retained topic and first delivers the retained events from offset on,
+// which is OffsetEarliest, OffsetLatest or the offset of a retained event. Replayed and new events reach
+// the subscriber in offset order without gaps, each carrying its offset in the OffsetHeader message header
+func (this *EventHub) SubscribeFrom(topic string, subscriber *actor.PID, offset uint64) (*SubscriptionHandle, error) {
+	ts := this.topic(topic, false)
+	if ts == nil || ts.retention() == nil {
+		return nil, ErrTopicNotRetained
+	}
+	retention := ts.retention()
+
+	//publishers of the topic wait until the replay is done and the subscription is visible
+	ts.durable.Lock()
+	defer ts.durable.Unlock()
+
+	first, next := retention.Bounds()
+	switch offset {
+	case OffsetEarliest:
+		offset = first
+	case OffsetLatest:
+		offset = next
+	}
+	if offset < first || offset > next {
+		return nil, ErrOffsetOutOfRange
+	}
+	if offset < next {
+		err := retention.Read(offset, func(event *Event) bool {
+			deliver(subscriber, event)
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return this.Subscribe(topic, subscriber), nil
+}
+
+// retention returns the retention log of the topic, nil unless the topic is retained
+func (ts *topicSubscribers) retention() RetentionLog {
+	if log, ok := ts.log.Load().(RetentionLog); ok {
+		return log
+	}
+	return nil
+}
+
+// ringLog is a RetentionLog keeping the latest events in memory
+type ringLog struct {
+	sync.RWMutex
+	events []*Event
+	next   uint64
+}
+
+// NewRingLog returns a RetentionLog which keeps the latest capacity events in memory
+func NewRingLog(capacity int) RetentionLog {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &ringLog{events: make([]*Event, capacity), next: 1}
+}
+
+func (r *ringLog) Append(event *Event) (*Event, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	retained := *event
+	retained.Offset = r.next
+	r.events[r.next%uint64(len(r.events))] = &retained
+	r.next++
+	return &retained, nil
+}
+
+func (r *ringLog) Bounds() (first, next uint64) {
+	r.RLock()
+	defer r.RUnlock()
+	return r.bounds()
+}
+
+func (r *ringLog) bounds() (first, next uint64) {
+	if r.next <= uint64(len(r.events)) {
+		return 1, r.next
+	}
+	return r.next - uint64(len(r.events)), r.next
+}
+
+func (r *ringLog) Read(from uint64, fn func(*Event) bool) error {
+	r.RLock()
+	first, next := r.bounds()
+	if from < first || from > next {
+		r.RUnlock()
+		return ErrOffsetOutOfRange
+	}
+	events := make([]*Event, 0, next-from)
+	for offset := from; offset < next; offset++ {
+		events = append(events, r.events[offset%uint64(len(r.events))])
+	}
+	r.RUnlock()
+
+	for _, event := range events {
+		if !fn(event) {
+			break
+		}
+	}
+	return nil
+}
+
+func (r *ringLog) Close() error {
+	return nil
+}
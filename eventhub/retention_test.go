@@ -0,0 +1,249 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"io/ioutil"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+// offsetProcess reports the offset header of every message it receives
+type offsetProcess struct {
+	offsets chan uint64
+}
+
+func (p *offsetProcess) SendUserMessage(pid *actor.PID, message interface{}) {
+	header, _, _ := actor.UnwrapEnvelope(message)
+	var offset uint64
+	if header != nil {
+		offset, _ = strconv.ParseUint(header.Get(OffsetHeader), 10, 64)
+	}
+	p.offsets <- offset
+}
+
+func (p *offsetProcess) SendSystemMessage(pid *actor.PID, message interface{}) {}
+
+func (p *offsetProcess) Stop(pid *actor.PID) {}
+
+func readOffsets(t *testing.T, log RetentionLog, from uint64) []uint64 {
+	var offsets []uint64
+	err := log.Read(from, func(event *Event) bool {
+		offsets = append(offsets, event.Offset)
+		return true
+	})
+	assert.NoError(t, err)
+	return offsets
+}
+
+func TestRingLog(t *testing.T) {
+	log := NewRingLog(3)
+	first, next := log.Bounds()
+	assert.Equal(t, uint64(1), first)
+	assert.Equal(t, uint64(1), next)
+
+	for i := 0; i < 5; i++ {
+		event := &Event{Topic: "topic", Message: i}
+		retained, err := log.Append(event)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(i+1), retained.Offset)
+		assert.Equal(t, uint64(0), event.Offset)
+	}
+	first, next = log.Bounds()
+	assert.Equal(t, uint64(3), first)
+	assert.Equal(t, uint64(6), next)
+	assert.Equal(t, []uint64{4, 5}, readOffsets(t, log, 4))
+	assert.Equal(t, ErrOffsetOutOfRange, log.Read(2, func(*Event) bool { return true }))
+}
+
+func TestFileLog_Recover(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eventhub")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	log, err := NewFileLog(dir, 2, 2)
+	assert.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		_, err := log.Append(&Event{Topic: "topic", Message: &ClusterSubscriptions{Address: strconv.Itoa(i)}})
+		assert.NoError(t, err)
+	}
+	_, err = log.Append(&Event{Topic: "topic", Message: "not proto"})
+	assert.Equal(t, ErrNotSerializable, err)
+	assert.NoError(t, log.Close())
+
+	//simulate a record cut short by a crash
+	file, err := os.OpenFile(log.(*fileLog).path(5), os.O_WRONLY|os.O_APPEND, 0644)
+	assert.NoError(t, err)
+	file.Write([]byte{0, 0, 1})
+	file.Close()
+
+	log, err = NewFileLog(dir, 2, 2)
+	assert.NoError(t, err)
+	defer log.Close()
+	first, next := log.Bounds()
+	assert.Equal(t, uint64(3), first)
+	assert.Equal(t, uint64(6), next)
+
+	_, err = log.Append(&Event{Topic: "topic", Message: &ClusterSubscriptions{Address: "5"}})
+	assert.NoError(t, err)
+	var addresses []string
+	log.Read(4, func(event *Event) bool {
+		addresses = append(addresses, event.Message.(*ClusterSubscriptions).Address)
+		return true
+	})
+	assert.Equal(t, []string{"3", "4", "5"}, addresses)
+}
+
+func TestFileLog_ReplayHeader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eventhub")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	log, err := NewFileLog(dir, 2, 0)
+	assert.NoError(t, err)
+	for i, kind := range []string{"selected", "other", "selected"} {
+		event := &Event{Topic: "topic", Message: &ClusterSubscriptions{Address: strconv.Itoa(i)}, Header: map[string]string{"kind": kind}}
+		_, err := log.Append(event)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, log.Close())
+
+	log, err = NewFileLog(dir, 2, 0)
+	assert.NoError(t, err)
+	defer log.Close()
+	sel, err := parseSelector("kind = 'selected'")
+	assert.NoError(t, err)
+	filter := headerFilter(sel)
+	var addresses []string
+	assert.NoError(t, log.Read(OffsetEarliest+1, func(event *Event) bool {
+		if filter(event) {
+			addresses = append(addresses, event.Message.(*ClusterSubscriptions).Address)
+		}
+		return true
+	}))
+	assert.Equal(t, []string{"0", "2"}, addresses)
+}
+
+func TestEventHub_PublishRetentionError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eventhub")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	log, err := NewFileLog(dir, 2, 0, WithFileSync(true))
+	assert.NoError(t, err)
+	defer log.Close()
+	hub := newTestHub()
+	assert.NoError(t, hub.Retain("topic", log))
+	var wg sync.WaitGroup
+	var count int64
+	hub.Subscribe("topic", spawnCounter(&wg, &count))
+
+	//a string is not a proto.Message, the event is neither retained nor sent
+	assert.Equal(t, ErrNotSerializable, hub.Publish(&Event{Topic: "topic", Message: "not proto"}))
+	assert.NoError(t, hub.Publish(&Event{Topic: "topic", Message: &ClusterSubscriptions{Address: "1"}}))
+	assert.Equal(t, int64(0), count)
+	first, next := log.Bounds()
+	assert.Equal(t, uint64(1), first)
+	assert.Equal(t, uint64(2), next)
+}
+
+func TestEventHub_SubscribeFrom(t *testing.T) {
+	hub := newTestHub()
+	_, err := hub.SubscribeFrom("topic", actor.NewLocalPID("subscriber"), OffsetEarliest)
+	assert.Equal(t, ErrTopicNotRetained, err)
+	assert.NoError(t, hub.Retain("topic", NewRingLog(4)))
+	assert.Equal(t, ErrTopicRetained, hub.Retain("topic", NewRingLog(4)))
+
+	for i := 0; i < 6; i++ {
+		hub.Publish(&Event{Topic: "topic", Message: "event"})
+	}
+
+	offsets := make(chan uint64, 100)
+	pid, _ := actor.ProcessRegistry.Add(&offsetProcess{offsets: offsets}, "offsets"+actor.ProcessRegistry.NextId())
+	_, err = hub.SubscribeFrom("topic", pid, 2)
+	assert.Equal(t, ErrOffsetOutOfRange, err)
+	_, err = hub.SubscribeFrom("topic", pid, OffsetEarliest)
+	assert.NoError(t, err)
+
+	for i := 0; i < 4; i++ {
+		hub.Publish(&Event{Topic: "topic", Message: "event"})
+	}
+
+	for expected := uint64(3); expected <= 10; expected++ {
+		select {
+		case offset := <-offsets:
+			assert.Equal(t, expected, offset)
+		case <-time.After(time.Second):
+			assert.Fail(t, "timed out waiting for offset "+strconv.FormatUint(expected, 10))
+			return
+		}
+	}
+}
+
+func TestEventHub_PublishSameEvent(t *testing.T) {
+	hub := newTestHub()
+	assert.NoError(t, hub.Retain("topic", NewRingLog(8)))
+	offsets := make(chan uint64, 100)
+	pid, _ := actor.ProcessRegistry.Add(&offsetProcess{offsets: offsets}, "offsets"+actor.ProcessRegistry.NextId())
+	hub.Subscribe("topic", pid)
+
+	//every publication of the event is retained and delivered with its own offset
+	event := &Event{Topic: "topic", Message: "event"}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, hub.Publish(event))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, uint64(0), event.Offset)
+
+	received := make(map[uint64]bool)
+	for len(received) < 4 {
+		select {
+		case offset := <-offsets:
+			received[offset] = true
+		case <-time.After(time.Second):
+			assert.Fail(t, "timed out waiting for the offsets")
+			return
+		}
+	}
+	assert.Equal(t, map[uint64]bool{1: true, 2: true, 3: true, 4: true}, received)
+}
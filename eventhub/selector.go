@@ -0,0 +1,251 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// selector is a compiled header selector expression.
+//
+// The grammar is
+//
+//	expr    = term { "OR" term }
+//	term    = factor { "AND" factor }
+//	factor  = "NOT" factor | "(" expr ")" | header ( "=" value | "!=" value | "IN" "(" value { "," value } ")" | "EXISTS" )
+//
+// where header is a header name made of letters, digits, '-', '_' and '.', and value is a single quoted string.
+// Keywords are case insensitive and a missing header compares as the empty string, e.g.
+//
+//	type = 'block' AND (height-mod IN ('0', '5') OR NOT priority EXISTS)
+type selector func(header map[string]string) bool
+
+// parseSelector compiles a header selector expression
+func parseSelector(expr string) (selector, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &selectorParser{expr: expr, tokens: tokens}
+	sel, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, p.errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	return sel, nil
+}
+
+type tokenKind int
+
+const (
+	tokenIdent tokenKind = iota
+	tokenString
+	tokenSymbol
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')' || r == ',' || r == '=':
+			tokens = append(tokens, token{kind: tokenSymbol, text: string(r)})
+			i++
+		case r == '!' && i+1 < len(runes) && runes[i+1] == '=':
+			tokens = append(tokens, token{kind: tokenSymbol, text: "!="})
+			i += 2
+		case r == '\'':
+			//a quote inside a value is written twice
+			var value []rune
+			for i++; ; i++ {
+				if i >= len(runes) {
+					return nil, fmt.Errorf("eventhub: selector %q: unterminated string", expr)
+				}
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						value = append(value, '\'')
+						i++
+						continue
+					}
+					i++
+					break
+				}
+				value = append(value, runes[i])
+			}
+			tokens = append(tokens, token{kind: tokenString, text: string(value)})
+		case isIdentRune(r):
+			start := i
+			for i < len(runes) && isIdentRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[start:i])})
+		default:
+			return nil, fmt.Errorf("eventhub: selector %q: unexpected %q", expr, r)
+		}
+	}
+	return tokens, nil
+}
+
+func isIdentRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' || r == '.'
+}
+
+type selectorParser struct {
+	expr   string
+	tokens []token
+	pos    int
+}
+
+func (p *selectorParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("eventhub: selector %q: %s", p.expr, fmt.Sprintf(format, args...))
+}
+
+// accept consumes the next token if it is the keyword or symbol text
+func (p *selectorParser) accept(text string) bool {
+	if p.pos < len(p.tokens) && p.tokens[p.pos].kind != tokenString && strings.EqualFold(p.tokens[p.pos].text, text) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *selectorParser) next(kind tokenKind, what string) (string, error) {
+	if p.pos >= len(p.tokens) {
+		return "", p.errorf("expected %s at the end", what)
+	}
+	tok := p.tokens[p.pos]
+	if tok.kind != kind {
+		return "", p.errorf("expected %s, got %q", what, tok.text)
+	}
+	p.pos++
+	return tok.text, nil
+}
+
+func (p *selectorParser) or() (selector, error) {
+	left, err := p.and()
+	for err == nil && p.accept("OR") {
+		var right selector
+		if right, err = p.and(); err == nil {
+			l := left
+			left = func(header map[string]string) bool { return l(header) || right(header) }
+		}
+	}
+	return left, err
+}
+
+func (p *selectorParser) and() (selector, error) {
+	left, err := p.factor()
+	for err == nil && p.accept("AND") {
+		var right selector
+		if right, err = p.factor(); err == nil {
+			l := left
+			left = func(header map[string]string) bool { return l(header) && right(header) }
+		}
+	}
+	return left, err
+}
+
+func (p *selectorParser) factor() (selector, error) {
+	if p.accept("NOT") {
+		inner, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		return func(header map[string]string) bool { return !inner(header) }, nil
+	}
+	if p.accept("(") {
+		inner, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, p.errorf("expected )")
+		}
+		return inner, nil
+	}
+
+	key, err := p.next(tokenIdent, "header name")
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case p.accept("="):
+		value, err := p.next(tokenString, "quoted value")
+		if err != nil {
+			return nil, err
+		}
+		return func(header map[string]string) bool { return header[key] == value }, nil
+	case p.accept("!="):
+		value, err := p.next(tokenString, "quoted value")
+		if err != nil {
+			return nil, err
+		}
+		return func(header map[string]string) bool { return header[key] != value }, nil
+	case p.accept("EXISTS"):
+		return func(header map[string]string) bool {
+			_, ok := header[key]
+			return ok
+		}, nil
+	case p.accept("IN"):
+		if !p.accept("(") {
+			return nil, p.errorf("expected ( after IN")
+		}
+		values := make(map[string]bool)
+		for {
+			value, err := p.next(tokenString, "quoted value")
+			if err != nil {
+				return nil, err
+			}
+			values[value] = true
+			if p.accept(")") {
+				break
+			}
+			if !p.accept(",") {
+				return nil, p.errorf("expected , or )")
+			}
+		}
+		return func(header map[string]string) bool { return values[header[key]] }, nil
+	}
+	return nil, p.errorf("expected =, !=, IN or EXISTS after %q", key)
+}
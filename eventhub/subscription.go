@@ -0,0 +1,104 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"sync"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+)
+
+// pidKey identifies a subscriber by value, two PIDs with the same Address and Id are the same subscriber
+func pidKey(pid *actor.PID) string {
+	return pid.Address + "#" + pid.Id
+}
+
+// subscription is a single registration of a subscriber for a topic
+type subscription struct {
+	id    uint64
+	key   string
+	pid   *actor.PID
+	topic string
+	// node marks the subscription of a remote node, pid is the cluster peer of that node
+	node bool
+	// filter drops the events the subscriber is not interested in, nil accepts all of them
+	filter Predicate
+	// selector is the expression filter was compiled from, empty for unfiltered and predicate subscriptions
+	selector string
+	// load is the mailbox backlog of the subscriber for PublishPolicyLeastLoaded, nil if unknown
+	load *MailboxLoad
+	// backpressure is the overflow policy of the subscriber, nil if events are always delivered
+	backpressure *backpressure
+}
+
+func (sub *subscription) is(pid *actor.PID) bool {
+	return sub.key == pidKey(pid)
+}
+
+// close releases the resources of a subscription removed from the hub
+func (sub *subscription) close() {
+	if sub.backpressure != nil {
+		sub.backpressure.stop()
+	}
+}
+
+// SubscriptionHandle is returned from Subscribe.
+//
+// It can be used to unsubscribe exactly the registration it was returned for, even if the same PID
+// subscribed to the topic more than once
+type SubscriptionHandle struct {
+	hub *EventHub
+	sub *subscription
+	// stop is called once on Unsubscribe, e.g. to stop an actor spawned for the subscription
+	stop     func()
+	stopOnce sync.Once
+}
+
+// Topic returns the topic, or topic pattern, of the subscription
+func (h *SubscriptionHandle) Topic() string {
+	return h.sub.topic
+}
+
+// Subscriber returns the PID of the subscriber
+func (h *SubscriptionHandle) Subscriber() *actor.PID {
+	return h.sub.pid
+}
+
+// Unsubscribe removes the subscription from the hub. Calling it more than once has no effect
+func (h *SubscriptionHandle) Unsubscribe() {
+	h.hub.unsubscribe(h.sub.topic, func(sub *subscription) bool {
+		return sub == h.sub
+	})
+	if h.stop != nil {
+		h.stopOnce.Do(h.stop)
+	}
+}
@@ -0,0 +1,121 @@
+//go:build go1.18
+// +build go1.18
+
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+)
+
+var (
+	ErrTopicType    = errors.New("eventhub: topic is typed with another message type")
+	ErrTopicPattern = errors.New("eventhub: wildcard topics cannot be typed")
+)
+
+// Topic is a typed view of a hub topic, only messages of type T can be published to it.
+//
+// Once a topic is typed, events published to it through the untyped API with a message which is not
+// a T are rejected with ErrTopicType
+type Topic[T any] struct {
+	hub  *EventHub
+	name string
+}
+
+// NewTopic types the topic of the hub with T, it fails if the topic was typed with another type
+func NewTopic[T any](hub *EventHub, name string) (*Topic[T], error) {
+	if hub.isPattern(name) {
+		return nil, ErrTopicPattern
+	}
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	ts := hub.topic(name, true)
+
+	ts.Lock()
+	defer ts.Unlock()
+	if current, ok := ts.messageType.Load().(*messageType); ok {
+		if current.typ != typ {
+			return nil, ErrTopicType
+		}
+	} else {
+		ts.messageType.Store(&messageType{
+			typ: typ,
+			accepts: func(message interface{}) bool {
+				_, ok := message.(T)
+				return ok
+			},
+		})
+	}
+	return &Topic[T]{hub: hub, name: name}, nil
+}
+
+// Name returns the name of the topic
+func (t *Topic[T]) Name() string {
+	return t.name
+}
+
+// Publish publishes the message with the default policy of the hub
+func (t *Topic[T]) Publish(message T) error {
+	return t.hub.Publish(&Event{Topic: t.name, Message: message, Policy: PublishPolicyDefault})
+}
+
+// PublishEvent publishes the message from publisher with the policy
+func (t *Topic[T]) PublishEvent(publisher *actor.PID, message T, policy PublishPolicy) error {
+	return t.hub.Publish(&Event{Publisher: publisher, Topic: t.name, Message: message, Policy: policy})
+}
+
+// Subscribe subscribes the actor to the topic, it receives messages of type T only
+func (t *Topic[T]) Subscribe(subscriber *actor.PID) *SubscriptionHandle {
+	return t.hub.Subscribe(t.name, subscriber)
+}
+
+// SubscribeFunc calls fn with every message of the topic, one message at a time from an actor
+// spawned for the subscription and stopped on Unsubscribe
+func (t *Topic[T]) SubscribeFunc(fn func(T)) (*SubscriptionHandle, error) {
+	pid, err := t.hub.system().SpawnPrefix(actor.FromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case actor.SystemMessage, actor.AutoReceiveMessage:
+			//ignore
+		case T:
+			fn(msg)
+		}
+	}), "topic-"+t.name)
+	if err != nil {
+		return nil, err
+	}
+	handle := t.hub.Subscribe(t.name, pid)
+	handle.stop = pid.Stop
+	return handle, nil
+}
@@ -0,0 +1,81 @@
+//go:build go1.18
+// +build go1.18
+
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type blockAdded struct {
+	Height int
+}
+
+func TestTopic_Typed(t *testing.T) {
+	hub := newTestHub()
+	topic, err := NewTopic[*blockAdded](hub, "block.added")
+	assert.NoError(t, err)
+	_, err = NewTopic[string](hub, "block.added")
+	assert.Equal(t, ErrTopicType, err)
+	_, err = NewTopic[*blockAdded](hub, "block.*")
+	assert.Equal(t, ErrTopicPattern, err)
+
+	heights := make(chan int, 10)
+	handle, err := topic.SubscribeFunc(func(block *blockAdded) {
+		heights <- block.Height
+	})
+	assert.NoError(t, err)
+
+	topic.Publish(&blockAdded{Height: 1})
+	assert.Equal(t, ErrTopicType, hub.Publish(&Event{Topic: "block.added", Message: "not a block"}))
+	assert.NoError(t, hub.Publish(&Event{Topic: "block.added", Message: &blockAdded{Height: 2}}))
+	for _, expected := range []int{1, 2} {
+		select {
+		case height := <-heights:
+			assert.Equal(t, expected, height)
+		case <-time.After(time.Second):
+			assert.Fail(t, "timed out waiting for a block")
+			return
+		}
+	}
+
+	handle.Unsubscribe()
+	handle.Unsubscribe()
+	topic.Publish(&blockAdded{Height: 3})
+	time.Sleep(20 * time.Millisecond)
+	assert.Len(t, heights, 0)
+}
@@ -0,0 +1,261 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// TopicSeparator separates the levels of a hierarchical topic, e.g. "block.committed.shard3"
+	TopicSeparator = "."
+	// SingleLevelWildcard matches exactly one topic level
+	SingleLevelWildcard = "*"
+	// MultiLevelWildcard matches zero or more topic levels
+	MultiLevelWildcard = "#"
+)
+
+// IsWildcardTopic reports whether the topic contains a single or multi level wildcard
+func IsWildcardTopic(topic string) bool {
+	for _, level := range strings.Split(topic, TopicSeparator) {
+		if level == SingleLevelWildcard || level == MultiLevelWildcard {
+			return true
+		}
+	}
+	return false
+}
+
+type topicNode struct {
+	// policy state of the events matched by the pattern of the node
+	policyState
+	children    map[string]*topicNode
+	subscribers []*subscription
+}
+
+func newTopicNode() *topicNode {
+	return &topicNode{children: make(map[string]*topicNode)}
+}
+
+// topicTrie indexes wildcard subscriptions by topic level
+type topicTrie struct {
+	sync.RWMutex
+	root  *topicNode
+	count int32
+}
+
+func newTopicTrie() *topicTrie {
+	return &topicTrie{root: newTopicNode()}
+}
+
+// Empty reports whether the trie holds no subscriptions, without taking the lock
+func (t *topicTrie) Empty() bool {
+	return atomic.LoadInt32(&t.count) == 0
+}
+
+func (t *topicTrie) Insert(pattern string, sub *subscription) {
+	t.Lock()
+	defer t.Unlock()
+
+	node := t.root
+	for _, level := range strings.Split(pattern, TopicSeparator) {
+		child, ok := node.children[level]
+		if !ok {
+			child = newTopicNode()
+			node.children[level] = child
+		}
+		node = child
+	}
+	node.subscribers = append(node.subscribers, sub)
+	atomic.AddInt32(&t.count, 1)
+}
+
+// Remove removes the subscriptions of the pattern accepted by match and returns them
+func (t *topicTrie) Remove(pattern string, match func(*subscription) bool) []*subscription {
+	t.Lock()
+	defer t.Unlock()
+
+	levels := strings.Split(pattern, TopicSeparator)
+	path := make([]*topicNode, 0, len(levels)+1)
+	node := t.root
+	path = append(path, node)
+	for _, level := range levels {
+		child, ok := node.children[level]
+		if !ok {
+			return nil
+		}
+		node = child
+		path = append(path, node)
+	}
+
+	removed := t.removeFrom(node, match, nil)
+	if len(removed) > 0 {
+		t.prune(path, levels)
+	}
+	return removed
+}
+
+// RemoveAll removes the subscriptions accepted by match from every pattern and returns them
+func (t *topicTrie) RemoveAll(match func(*subscription) bool) []*subscription {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.removeAll(t.root, match, nil)
+}
+
+func (t *topicTrie) removeFrom(node *topicNode, match func(*subscription) bool, removed []*subscription) []*subscription {
+	for i := 0; i < len(node.subscribers); i++ {
+		if sub := node.subscribers[i]; match(sub) {
+			node.subscribers = append(node.subscribers[:i:i], node.subscribers[i+1:]...)
+			atomic.AddInt32(&t.count, -1)
+			removed = append(removed, sub)
+			i--
+		}
+	}
+	return removed
+}
+
+func (t *topicTrie) removeAll(node *topicNode, match func(*subscription) bool, removed []*subscription) []*subscription {
+	removed = t.removeFrom(node, match, removed)
+	for level, child := range node.children {
+		removed = t.removeAll(child, match, removed)
+		if len(child.subscribers) == 0 && len(child.children) == 0 {
+			delete(node.children, level)
+		}
+	}
+	return removed
+}
+
+// prune removes the nodes on path that no longer hold subscribers or children
+func (t *topicTrie) prune(path []*topicNode, levels []string) {
+	for i := len(path) - 1; i > 0; i-- {
+		node := path[i]
+		if len(node.subscribers) > 0 || len(node.children) > 0 {
+			return
+		}
+		delete(path[i-1].children, levels[i-1])
+	}
+}
+
+// Subscriptions returns the subscriptions of the pattern
+func (t *topicTrie) Subscriptions(pattern string) []*subscription {
+	t.RLock()
+	defer t.RUnlock()
+
+	node := t.root
+	for _, level := range strings.Split(pattern, TopicSeparator) {
+		child, ok := node.children[level]
+		if !ok {
+			return nil
+		}
+		node = child
+	}
+	return append([]*subscription(nil), node.subscribers...)
+}
+
+// Each calls fn with every subscription of the trie, fn must not modify the trie
+func (t *topicTrie) Each(fn func(*subscription)) {
+	t.RLock()
+	defer t.RUnlock()
+
+	t.each(t.root, fn)
+}
+
+func (t *topicTrie) each(node *topicNode, fn func(*subscription)) {
+	for _, sub := range node.subscribers {
+		fn(sub)
+	}
+	for _, child := range node.children {
+		t.each(child, fn)
+	}
+}
+
+// Match returns the subscribers of every pattern matching the topic
+func (t *topicTrie) Match(topic string) []*subscription {
+	subscribers, _ := t.match(topic)
+	return subscribers
+}
+
+// match returns the subscribers of every pattern matching the topic and the policy state
+// of the first matching pattern, or nil if no pattern matches
+func (t *topicTrie) match(topic string) ([]*subscription, *policyState) {
+	if t.Empty() {
+		return nil, nil
+	}
+
+	t.RLock()
+	defer t.RUnlock()
+
+	var nodes []*topicNode
+	t.matchNodes(t.root, strings.Split(topic, TopicSeparator), &nodes)
+
+	var subscribers []*subscription
+	var state *policyState
+	for _, node := range nodes {
+		if len(node.subscribers) == 0 {
+			continue
+		}
+		if state == nil {
+			state = &node.policyState
+		}
+		subscribers = append(subscribers, node.subscribers...)
+	}
+	return subscribers, state
+}
+
+func (t *topicTrie) matchNodes(node *topicNode, levels []string, nodes *[]*topicNode) {
+	if multi, ok := node.children[MultiLevelWildcard]; ok {
+		//the multi level wildcard consumes zero or more of the remaining levels
+		for i := 0; i <= len(levels); i++ {
+			t.matchNodes(multi, levels[i:], nodes)
+		}
+	}
+
+	if len(levels) == 0 {
+		//a node may be reached through more than one path, e.g. "#.#", but must only match once
+		for _, matched := range *nodes {
+			if matched == node {
+				return
+			}
+		}
+		*nodes = append(*nodes, node)
+		return
+	}
+
+	if child, ok := node.children[levels[0]]; ok {
+		t.matchNodes(child, levels[1:], nodes)
+	}
+	if single, ok := node.children[SingleLevelWildcard]; ok {
+		t.matchNodes(single, levels[1:], nodes)
+	}
+}
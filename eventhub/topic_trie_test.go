@@ -0,0 +1,93 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"testing"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSubscription(id string) *subscription {
+	pid := actor.NewLocalPID(id)
+	return &subscription{key: pidKey(pid), pid: pid}
+}
+
+func TestTopicTrie_Match(t *testing.T) {
+	trie := newTopicTrie()
+	single := newTestSubscription("single")
+	multi := newTestSubscription("multi")
+	middle := newTestSubscription("middle")
+
+	trie.Insert("block.*", single)
+	trie.Insert("block.#", multi)
+	trie.Insert("block.*.shard3", middle)
+
+	assert.Equal(t, []*subscription{multi}, trie.Match("block"))
+	assert.ElementsMatch(t, []*subscription{single, multi}, trie.Match("block.committed"))
+	assert.ElementsMatch(t, []*subscription{multi, middle}, trie.Match("block.committed.shard3"))
+	assert.Empty(t, trie.Match("tx.committed"))
+}
+
+func TestTopicTrie_MultiLevelMatchesOnce(t *testing.T) {
+	trie := newTopicTrie()
+	sub := newTestSubscription("pid")
+
+	trie.Insert("#.#", sub)
+
+	assert.Equal(t, []*subscription{sub}, trie.Match("a.b.c"))
+}
+
+func TestTopicTrie_Remove(t *testing.T) {
+	trie := newTopicTrie()
+	sub := newTestSubscription("pid")
+	pid := actor.NewLocalPID("pid")
+	match := func(s *subscription) bool { return s.is(pid) }
+
+	trie.Insert("block.*", sub)
+	trie.Insert("tx.#", sub)
+	assert.Empty(t, trie.Remove("block.#", match))
+	assert.Equal(t, []*subscription{sub}, trie.Remove("block.*", match))
+	assert.Empty(t, trie.Match("block.committed"))
+
+	trie.RemoveAll(match)
+	assert.True(t, trie.Empty())
+	assert.Empty(t, trie.root.children)
+}
+
+func TestIsWildcardTopic(t *testing.T) {
+	assert.True(t, IsWildcardTopic("block.*"))
+	assert.True(t, IsWildcardTopic("#"))
+	assert.False(t, IsWildcardTopic("block.committed"))
+	assert.False(t, IsWildcardTopic("block.a*"))
+}
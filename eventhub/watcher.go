@@ -0,0 +1,124 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventhub
+
+import (
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+	"github.com/OnyxPay/OnyxChain-eventbus/log"
+)
+
+// SubscriberTerminatedEvent is published on the EventStream when the subscriptions of a terminated
+// subscriber were removed from an EventHub
+type SubscriberTerminatedEvent struct {
+	Hub               *EventHub
+	Subscriber        *actor.PID
+	Topics            []string // the topics, or topic patterns, the subscriber was removed from
+	AddressTerminated bool     // the subscriber terminated because its remote endpoint terminated
+}
+
+type subscriberAdded struct {
+	pid *actor.PID
+}
+
+type subscriberRemoved struct {
+	pid *actor.PID
+}
+
+// subscriberWatcher watches every subscriber of a hub while it holds at least one subscription
+type subscriberWatcher struct {
+	hub           *EventHub
+	subscriptions map[string]int
+}
+
+func (this *EventHub) subscriberWatcher() *actor.PID {
+	this.watcherOnce.Do(func() {
+		props := actor.FromProducer(func() actor.Actor {
+			return &subscriberWatcher{hub: this}
+		})
+		pid, err := this.system().SpawnPrefix(props, "EventHubWatcher")
+		if err != nil {
+			plog.Error("failed to spawn subscriber watcher", log.Error(err))
+		}
+		this.watcher = pid
+	})
+	return this.watcher
+}
+
+func (state *subscriberWatcher) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		state.subscriptions = make(map[string]int)
+	case *subscriberAdded:
+		//additions and removals may arrive out of order from concurrent callers, only the transitions count
+		key := pidKey(msg.pid)
+		n := state.subscriptions[key] + 1
+		if n == 0 {
+			delete(state.subscriptions, key)
+		} else {
+			state.subscriptions[key] = n
+		}
+		if n == 1 {
+			ctx.Watch(msg.pid)
+		}
+	case *subscriberRemoved:
+		key := pidKey(msg.pid)
+		n := state.subscriptions[key] - 1
+		if n == 0 {
+			delete(state.subscriptions, key)
+			ctx.Unwatch(msg.pid)
+		} else {
+			state.subscriptions[key] = n
+		}
+	case *actor.Terminated:
+		delete(state.subscriptions, pidKey(msg.Who))
+		removed := state.hub.remove(func(sub *subscription) bool {
+			return sub.is(msg.Who)
+		})
+		if len(removed) == 0 {
+			return
+		}
+		state.hub.gossipChanged(removed)
+
+		topics := make([]string, len(removed))
+		for i, sub := range removed {
+			sub.close()
+			topics[i] = sub.topic
+		}
+		plog.Debug("removed terminated subscriber", log.Stringer("pid", msg.Who), log.Object("topics", topics))
+		state.hub.events().Publish(&SubscriberTerminatedEvent{
+			Hub:               state.hub,
+			Subscriber:        msg.Who,
+			Topics:            topics,
+			AddressTerminated: msg.AddressTerminated,
+		})
+	}
+}
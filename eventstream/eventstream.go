@@ -32,29 +32,58 @@ limitations under the License.
 package eventstream
 
 import (
+	"reflect"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Predicate is a function used to filter messages before being forwarded to a subscriber
 type Predicate func(evt interface{}) bool
 
-var es = &EventStream{}
+var es = New()
+
+// New returns an EventStream independent of the default one used by the package level functions
+func New() *EventStream {
+	return &EventStream{}
+}
+
+// Default returns the EventStream used by the package level functions
+func Default() *EventStream {
+	return es
+}
 
 func Subscribe(fn func(evt interface{})) *Subscription {
 	return es.Subscribe(fn)
 }
 
+func SubscribeType(typ interface{}, fn func(evt interface{})) *Subscription {
+	return es.SubscribeType(typ, fn)
+}
+
+// Unsubscribe removes sub from the EventStream it was subscribed to
 func Unsubscribe(sub *Subscription) {
-	es.Unsubscribe(sub)
+	sub.es.Unsubscribe(sub)
 }
 
 func Publish(event interface{}) {
 	es.Publish(event)
 }
 
+// Flush waits until the asynchronous subscriptions of the EventStream handled their queued events
+func Flush(timeout time.Duration) bool {
+	return es.Flush(timeout)
+}
+
+// EventStream delivers every published event to its subscriptions.
+//
+// The subscriptions are an immutable snapshot replaced on Subscribe and Unsubscribe, so Publish
+// calls the subscribers without holding the lock and subscribers may subscribe and unsubscribe from their callbacks.
+// Subscriptions of SubscribeType are indexed by the event type, Publish only visits the ones of the published type
 type EventStream struct {
 	sync.RWMutex
 	subscriptions []*Subscription
+	types         map[reflect.Type][]*Subscription
 }
 
 func (es *EventStream) Subscribe(fn func(evt interface{})) *Subscription {
@@ -64,59 +93,264 @@ func (es *EventStream) Subscribe(fn func(evt interface{})) *Subscription {
 		i:  len(es.subscriptions),
 		fn: fn,
 	}
-	es.subscriptions = append(es.subscriptions, sub)
+	es.subscriptions = appendSubscription(es.subscriptions, sub)
 	es.Unlock()
 	return sub
 }
 
-func (ps *EventStream) Unsubscribe(sub *Subscription) {
-	if sub.i == -1 {
-		return
+// SubscribeType subscribes fn to the events of a single concrete type, given as reflect.Type or as a sample value.
+//
+// Unlike a predicate or a type switch in fn, the subscription costs nothing on Publish of other event types
+func (es *EventStream) SubscribeType(typ interface{}, fn func(evt interface{})) *Subscription {
+	t, ok := typ.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(typ)
 	}
 
-	ps.Lock()
-	i := sub.i
-	l := len(ps.subscriptions) - 1
-
-	ps.subscriptions[i] = ps.subscriptions[l]
-	ps.subscriptions[i].i = i
-	ps.subscriptions[l] = nil
-	ps.subscriptions = ps.subscriptions[:l]
-	sub.i = -1
+	es.Lock()
+	if es.types == nil {
+		es.types = make(map[reflect.Type][]*Subscription)
+	}
+	sub := &Subscription{
+		es:  es,
+		i:   len(es.types[t]),
+		typ: t,
+		fn:  fn,
+	}
+	es.types[t] = appendSubscription(es.types[t], sub)
+	es.Unlock()
+	return sub
+}
 
-	// TODO(SGC): implement resizing
-	if len(ps.subscriptions) == 0 {
-		ps.subscriptions = nil
+func (ps *EventStream) Unsubscribe(sub *Subscription) {
+	ps.Lock()
+	if sub.i == -1 || sub.es != ps {
+		ps.Unlock()
+		return
 	}
 
+	if sub.typ == nil {
+		ps.subscriptions = removeSubscription(ps.subscriptions, sub)
+	} else if subs := removeSubscription(ps.types[sub.typ], sub); subs != nil {
+		ps.types[sub.typ] = subs
+	} else {
+		delete(ps.types, sub.typ)
+	}
 	ps.Unlock()
+
+	sub.stop()
 }
 
 func (ps *EventStream) Publish(evt interface{}) {
 	ps.RLock()
-	defer ps.RUnlock()
+	subs := ps.subscriptions
+	typed := ps.types[reflect.TypeOf(evt)]
+	ps.RUnlock()
+
+	for _, s := range subs {
+		if p := s.predicate(); p == nil || p(evt) {
+			s.deliver(evt)
+		}
+	}
+	for _, s := range typed {
+		if p := s.predicate(); p == nil || p(evt) {
+			s.deliver(evt)
+		}
+	}
+}
+
+// Flush waits until the asynchronous subscriptions handled the events queued so far, or the timeout expired.
+// It returns false on timeout
+func (ps *EventStream) Flush(timeout time.Duration) bool {
+	ps.RLock()
+	subs := ps.subscriptions
+	for _, typed := range ps.types {
+		subs = append(subs[:len(subs):len(subs)], typed...)
+	}
+	ps.RUnlock()
 
-	for _, s := range ps.subscriptions {
-		if s.p == nil || s.p(evt) {
-			s.fn(evt)
+	deadline := time.Now().Add(timeout)
+	for _, s := range subs {
+		if !s.Flush(time.Until(deadline)) {
+			return false
 		}
 	}
+	return true
+}
+
+// appendSubscription returns a copy of subs with sub appended, the slices published to Publish are never modified
+func appendSubscription(subs []*Subscription, sub *Subscription) []*Subscription {
+	res := make([]*Subscription, len(subs), len(subs)+1)
+	copy(res, subs)
+	return append(res, sub)
+}
+
+// removeSubscription returns a copy of subs without sub, moving the last subscription into its place
+func removeSubscription(subs []*Subscription, sub *Subscription) []*Subscription {
+	i := sub.i
+	l := len(subs) - 1
+	sub.i = -1
+	if l == 0 {
+		return nil
+	}
+
+	res := make([]*Subscription, l)
+	copy(res, subs[:l])
+	if i < l {
+		res[i] = subs[l]
+		res[i].i = i
+	}
+	return res
 }
 
+// OverflowPolicy is what an asynchronous subscription does with an event when its queue is full
+type OverflowPolicy int
+
+const (
+	// OverflowDrop drops the event
+	OverflowDrop OverflowPolicy = iota
+	// OverflowBlock blocks the publisher until the queue has space
+	OverflowBlock
+)
+
 // Subscription is returned from the Subscribe function.
 //
 // This value and can be passed to Unsubscribe when the observer is no longer interested in receiving messages
 type Subscription struct {
 	es *EventStream
 	i  int
-	fn func(event interface{})
-	p  Predicate
+	// typ is the event type of a SubscribeType subscription
+	typ reflect.Type
+	fn  func(event interface{})
+	p   atomic.Value
+	// async is the queue of an asynchronous subscription, nil for synchronous delivery
+	async atomic.Value
 }
 
 // WithPredicate sets a predicate to filter messages passed to the subscriber
 func (s *Subscription) WithPredicate(p Predicate) *Subscription {
+	s.p.Store(p)
+	return s
+}
+
+func (s *Subscription) predicate() Predicate {
+	p, _ := s.p.Load().(Predicate)
+	return p
+}
+
+// WithAsync makes the delivery asynchronous, published events are queued and passed to the subscriber
+// by a goroutine of the subscription. Once queueSize events are queued, overflow decides about new events.
+// Unsubscribe stops the goroutine and drops the queued events, Flush waits for them first
+func (s *Subscription) WithAsync(queueSize int, overflow OverflowPolicy) *Subscription {
+	if queueSize < 1 {
+		queueSize = 1
+	}
+	q := &asyncQueue{
+		events:   make(chan interface{}, queueSize),
+		overflow: overflow,
+		stopped:  make(chan struct{}),
+	}
 	s.es.Lock()
-	s.p = p
+	if s.async.Load() == nil {
+		s.async.Store(q)
+		go q.run(s.fn)
+	}
 	s.es.Unlock()
 	return s
 }
+
+// Dropped returns the number of events an asynchronous subscription dropped because its queue was full
+func (s *Subscription) Dropped() uint64 {
+	if q := s.queue(); q != nil {
+		return atomic.LoadUint64(&q.dropped)
+	}
+	return 0
+}
+
+// Flush waits until an asynchronous subscription handled the events queued so far, or the timeout expired.
+// It returns false on timeout
+func (s *Subscription) Flush(timeout time.Duration) bool {
+	q := s.queue()
+	if q == nil {
+		return true
+	}
+	flushed := make(flushMarker)
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case q.events <- flushed:
+	case <-q.stopped:
+		return true
+	case <-timer.C:
+		return false
+	}
+	select {
+	case <-flushed:
+		return true
+	case <-q.stopped:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+func (s *Subscription) queue() *asyncQueue {
+	q, _ := s.async.Load().(*asyncQueue)
+	return q
+}
+
+func (s *Subscription) deliver(evt interface{}) {
+	if q := s.queue(); q != nil {
+		q.push(evt)
+		return
+	}
+	s.fn(evt)
+}
+
+func (s *Subscription) stop() {
+	if q := s.queue(); q != nil {
+		q.stopOnce.Do(func() { close(q.stopped) })
+	}
+}
+
+// flushMarker is queued by Flush and closed once the events queued before it were handled
+type flushMarker chan struct{}
+
+type asyncQueue struct {
+	events   chan interface{}
+	overflow OverflowPolicy
+	dropped  uint64
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+func (q *asyncQueue) push(evt interface{}) {
+	if q.overflow == OverflowBlock {
+		select {
+		case q.events <- evt:
+		case <-q.stopped:
+		}
+		return
+	}
+	select {
+	case q.events <- evt:
+	default:
+		atomic.AddUint64(&q.dropped, 1)
+	}
+}
+
+func (q *asyncQueue) run(fn func(evt interface{})) {
+	for {
+		select {
+		case evt := <-q.events:
+			if flushed, ok := evt.(flushMarker); ok {
+				close(flushed)
+				continue
+			}
+			fn(evt)
+		case <-q.stopped:
+			return
+		}
+	}
+}
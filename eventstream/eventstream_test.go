@@ -0,0 +1,143 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventstream
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSubscribeFromCallback(t *testing.T) {
+	stream := &EventStream{}
+	var inner int32
+	var sub *Subscription
+	sub = stream.Subscribe(func(evt interface{}) {
+		stream.Unsubscribe(sub)
+		stream.Subscribe(func(evt interface{}) {
+			atomic.AddInt32(&inner, 1)
+		})
+	})
+
+	done := make(chan struct{})
+	go func() {
+		stream.Publish(1)
+		stream.Publish(2)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("publish deadlocked")
+	}
+	if n := atomic.LoadInt32(&inner); n != 1 {
+		t.Fatalf("expected 1 event for the inner subscription, got %d", n)
+	}
+}
+
+func TestAsyncSubscription(t *testing.T) {
+	stream := &EventStream{}
+	release := make(chan struct{})
+	var received int32
+	sub := stream.Subscribe(func(evt interface{}) {
+		<-release
+		atomic.AddInt32(&received, 1)
+	}).WithAsync(2, OverflowDrop)
+
+	for i := 0; i < 5; i++ {
+		stream.Publish(i)
+	}
+	close(release)
+
+	if !stream.Flush(time.Second) {
+		t.Fatal("flush timed out")
+	}
+	// one event is being handled when the queue fills up
+	if n := atomic.LoadInt32(&received); n+int32(sub.Dropped()) != 5 || n < 2 {
+		t.Fatalf("received %d, dropped %d", n, sub.Dropped())
+	}
+	stream.Unsubscribe(sub)
+}
+
+type typedEvent struct{ n int }
+
+func TestSubscribeType(t *testing.T) {
+	stream := &EventStream{}
+	var typed, untyped []interface{}
+	sub := stream.SubscribeType((*typedEvent)(nil), func(evt interface{}) {
+		typed = append(typed, evt)
+	})
+	stream.Subscribe(func(evt interface{}) {
+		untyped = append(untyped, evt)
+	})
+
+	stream.Publish(&typedEvent{n: 1})
+	stream.Publish("other")
+	stream.Unsubscribe(sub)
+	stream.Publish(&typedEvent{n: 2})
+
+	if len(typed) != 1 || typed[0].(*typedEvent).n != 1 {
+		t.Fatalf("unexpected typed events %v", typed)
+	}
+	if len(untyped) != 3 {
+		t.Fatalf("unexpected untyped events %v", untyped)
+	}
+	if len(stream.types) != 0 {
+		t.Fatalf("expected the type index to be empty, got %v", stream.types)
+	}
+}
+
+func TestFlushTypedSubscription(t *testing.T) {
+	stream := &EventStream{}
+	release := make(chan struct{})
+	var received int32
+	sub := stream.SubscribeType((*typedEvent)(nil), func(evt interface{}) {
+		<-release
+		atomic.AddInt32(&received, 1)
+	}).WithAsync(10, OverflowBlock)
+
+	for i := 0; i < 3; i++ {
+		stream.Publish(&typedEvent{n: i})
+	}
+	if stream.Flush(10 * time.Millisecond) {
+		t.Fatal("expected flush to wait for the typed subscription")
+	}
+	close(release)
+
+	if !stream.Flush(time.Second) {
+		t.Fatal("flush timed out")
+	}
+	if n := atomic.LoadInt32(&received); n != 3 {
+		t.Fatalf("expected 3 events, got %d", n)
+	}
+	stream.Unsubscribe(sub)
+}
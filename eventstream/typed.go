@@ -0,0 +1,69 @@
+//go:build go1.18
+// +build go1.18
+
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package eventstream
+
+import "reflect"
+
+// Stream is a typed view of an EventStream, it publishes and receives events of type T only
+type Stream[T any] struct {
+	es *EventStream
+}
+
+// Typed returns the typed view of the EventStream, or of the default EventStream if es is nil
+func Typed[T any](stream *EventStream) *Stream[T] {
+	if stream == nil {
+		stream = es
+	}
+	return &Stream[T]{es: stream}
+}
+
+func (s *Stream[T]) Publish(evt T) {
+	s.es.Publish(evt)
+}
+
+// Subscribe calls fn with the published events of type T, the other events are skipped.
+// A concrete T is subscribed with SubscribeType, an interface T sees every event
+func (s *Stream[T]) Subscribe(fn func(evt T)) *Subscription {
+	if typ := reflect.TypeOf((*T)(nil)).Elem(); typ.Kind() != reflect.Interface {
+		return s.es.SubscribeType(typ, func(evt interface{}) {
+			fn(evt.(T))
+		})
+	}
+	return s.es.Subscribe(func(evt interface{}) {
+		if e, ok := evt.(T); ok {
+			fn(e)
+		}
+	})
+}
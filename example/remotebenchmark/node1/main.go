@@ -34,6 +34,7 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"log"
 	"runtime"
 	"sync"
 	"time"
@@ -79,7 +80,9 @@ func main() {
 	var wg sync.WaitGroup
 
 	messageCount := 50000
-	remote.Start("127.0.0.1:8081")
+	if err := remote.Start("127.0.0.1:8081"); err != nil {
+		log.Fatal(err)
+	}
 
 	props := actor.
 		FromProducer(newLocalActor(&wg, messageCount)).
@@ -34,6 +34,7 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"log"
 	"runtime"
 	"sync"
 	"time"
@@ -81,7 +82,9 @@ func main() {
 
 	messageCount := 50000
 
-	zmqremote.Start("127.0.0.1:8081")
+	if err := zmqremote.Start("127.0.0.1:8081"); err != nil {
+		log.Fatal(err)
+	}
 
 	props := actor.
 		FromProducer(newLocalActor(&wg, messageCount)).
@@ -33,6 +33,7 @@ package main
 
 import (
 	"fmt"
+	"log"
 	"runtime"
 	"time"
 
@@ -46,7 +47,9 @@ func main() {
 	runtime.GOMAXPROCS(runtime.NumCPU() * 1)
 	runtime.GC()
 
-	zmqremote.Start("127.0.0.1:8080")
+	if err := zmqremote.Start("127.0.0.1:8080"); err != nil {
+		log.Fatal(err)
+	}
 
 	var sender *actor.PID
 	props := actor.
@@ -33,6 +33,7 @@ package main
 
 import (
 	"fmt"
+	"log"
 	"runtime"
 	"sync"
 	"time"
@@ -79,7 +80,9 @@ func main() {
 
 	messageCount := 500
 
-	zmqremote.Start("127.0.0.1:8081")
+	if err := zmqremote.Start("127.0.0.1:8081"); err != nil {
+		log.Fatal(err)
+	}
 
 	props := actor.
 		FromProducer(newLocalActor(&wg, messageCount)).
@@ -69,7 +69,7 @@ func (q *Queue) Push(x interface{}) {
 	prev := (*node)(atomic.SwapPointer((*unsafe.Pointer)(unsafe.Pointer(&q.head)), unsafe.Pointer(n)))
 
 	// release node to consumer
-	prev.next = n
+	atomic.StorePointer((*unsafe.Pointer)(unsafe.Pointer(&prev.next)), unsafe.Pointer(n))
 }
 
 // Pop removes the item from the front of the queue or nil if the queue is empty
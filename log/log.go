@@ -55,6 +55,7 @@ type Logger struct {
 	level   Level
 	prefix  string
 	context []Field
+	stream  *Stream
 }
 
 func New(level Level, prefix string, context ...Field) *Logger {
@@ -80,9 +81,28 @@ func (l *Logger) With(fields ...Field) *Logger {
 		level:   l.level,
 		prefix:  l.prefix,
 		context: ctx,
+		stream:  l.stream,
 	}
 }
 
+// WithStream returns a copy of the logger publishing its events on stream instead of the default one,
+// a nil stream restores the default
+func (l *Logger) WithStream(stream *Stream) *Logger {
+	return &Logger{
+		level:   l.Level(),
+		prefix:  l.prefix,
+		context: l.context,
+		stream:  stream,
+	}
+}
+
+func (l *Logger) events() *Stream {
+	if l.stream != nil {
+		return l.stream
+	}
+	return es
+}
+
 func (l *Logger) Level() Level {
 	return Level(atomic.LoadInt32((*int32)(&l.level)))
 }
@@ -93,19 +113,19 @@ func (l *Logger) SetLevel(level Level) {
 
 func (l *Logger) Debug(msg string, fields ...Field) {
 	if l.Level() < InfoLevel {
-		es.Publish(Event{Time: time.Now(), Level: DebugLevel, Prefix: l.prefix, Message: msg, Context: l.context, Fields: fields})
+		l.events().Publish(Event{Time: time.Now(), Level: DebugLevel, Prefix: l.prefix, Message: msg, Context: l.context, Fields: fields})
 	}
 }
 
 func (l *Logger) Info(msg string, fields ...Field) {
 	if l.Level() < ErrorLevel {
-		es.Publish(Event{Time: time.Now(), Level: InfoLevel, Prefix: l.prefix, Message: msg, Context: l.context, Fields: fields})
+		l.events().Publish(Event{Time: time.Now(), Level: InfoLevel, Prefix: l.prefix, Message: msg, Context: l.context, Fields: fields})
 	}
 }
 
 func (l *Logger) Error(msg string, fields ...Field) {
 	if l.Level() < OffLevel {
-		es.Publish(Event{Time: time.Now(), Level: ErrorLevel, Prefix: l.prefix, Message: msg, Context: l.context, Fields: fields})
+		l.events().Publish(Event{Time: time.Now(), Level: ErrorLevel, Prefix: l.prefix, Message: msg, Context: l.context, Fields: fields})
 	}
 }
 
@@ -45,6 +45,53 @@ func TestLogger_With(t *testing.T) {
 	assert.Equal(t, []Field{{key: "first"}, {key: "second"}}, l.context)
 }
 
+func TestLogger_WithStream(t *testing.T) {
+	var events, defaultEvents []Event
+	stream := NewStream()
+	stream.Subscribe(func(evt Event) {
+		events = append(events, evt)
+	})
+	sub := Subscribe(func(evt Event) {
+		defaultEvents = append(defaultEvents, evt)
+	})
+	defer Unsubscribe(sub)
+
+	l := New(DebugLevel, "test").WithStream(stream)
+	l.Info("first")
+	l.With(Field{key: "second"}).Error("second")
+	l.WithStream(nil).Info("default")
+
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, "first", events[0].Message)
+		assert.Equal(t, []Field{{key: "second"}}, events[1].Context)
+	}
+	if assert.Len(t, defaultEvents, 1) {
+		assert.Equal(t, "default", defaultEvents[0].Message)
+	}
+}
+
+func TestStream_Unsubscribe(t *testing.T) {
+	var events []Event
+	stream := NewStream()
+	first := stream.Subscribe(func(evt Event) {})
+	second := stream.Subscribe(func(evt Event) {
+		events = append(events, evt)
+	})
+
+	// the default stream leaves the subscriptions of another stream alone
+	es.Unsubscribe(first)
+	Unsubscribe(first)
+	Unsubscribe(first)
+	stream.Publish(Event{Message: "first"})
+	stream.Unsubscribe(second)
+	stream.Publish(Event{Message: "second"})
+
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "first", events[0].Message)
+	}
+	assert.Empty(t, stream.subscriptions)
+}
+
 func Benchmark_OffLevel_TwoFields(b *testing.B) {
 	l := New(MinLevel, "")
 	for i := 0; i < b.N; i++ {
@@ -34,22 +34,29 @@ package log
 
 import "sync"
 
-var es = &eventStream{}
+var es = &Stream{}
+
+// NewStream returns a Stream independent of the default one, for the loggers returned by Logger.WithStream
+func NewStream() *Stream {
+	return &Stream{}
+}
 
 func Subscribe(fn func(evt Event)) *Subscription {
 	return es.Subscribe(fn)
 }
 
+// Unsubscribe removes sub from the Stream it was subscribed to
 func Unsubscribe(sub *Subscription) {
-	es.Unsubscribe(sub)
+	sub.es.Unsubscribe(sub)
 }
 
-type eventStream struct {
+// Stream passes the log events to its subscriptions, the package level Subscribe uses the default one
+type Stream struct {
 	sync.RWMutex
 	subscriptions []*Subscription
 }
 
-func (es *eventStream) Subscribe(fn func(evt Event)) *Subscription {
+func (es *Stream) Subscribe(fn func(evt Event)) *Subscription {
 	es.Lock()
 	sub := &Subscription{
 		es: es,
@@ -61,12 +68,13 @@ func (es *eventStream) Subscribe(fn func(evt Event)) *Subscription {
 	return sub
 }
 
-func (ps *eventStream) Unsubscribe(sub *Subscription) {
-	if sub.i == -1 {
+func (ps *Stream) Unsubscribe(sub *Subscription) {
+	ps.Lock()
+	if sub.i == -1 || sub.es != ps {
+		ps.Unlock()
 		return
 	}
 
-	ps.Lock()
 	i := sub.i
 	l := len(ps.subscriptions) - 1
 
@@ -84,7 +92,7 @@ func (ps *eventStream) Unsubscribe(sub *Subscription) {
 	ps.Unlock()
 }
 
-func (ps *eventStream) Publish(evt Event) {
+func (ps *Stream) Publish(evt Event) {
 	ps.RLock()
 	defer ps.RUnlock()
 
@@ -99,7 +107,7 @@ func (ps *eventStream) Publish(evt Event) {
 //
 // This value and can be passed to Unsubscribe when the observer is no longer interested in receiving messages
 type Subscription struct {
-	es *eventStream
+	es *Stream
 	i  int
 	fn func(event Event)
 	l  Level
@@ -41,17 +41,16 @@ import (
 )
 
 var (
-	nameLookup   = make(map[string]actor.Props)
-	activatorPid *actor.PID
+	nameLookup = make(map[string]actor.Props)
 )
 
-func spawnActivatorActor() {
-	props := actor.FromProducer(newActivatorActor()).WithGuardian(actor.RestartingSupervisorStrategy())
-	activatorPid, _ = actor.SpawnNamed(props, "activator")
+func (r *Remote) spawnActivatorActor() {
+	props := actor.FromProducer(newActivatorActor(r.system)).WithGuardian(actor.RestartingSupervisorStrategy())
+	r.activatorPid, _ = r.system.SpawnNamed(props, "activator")
 }
 
-func stopActivatorActor() {
-	activatorPid.GracefulStop()
+func (r *Remote) stopActivatorActor() {
+	r.activatorPid.GracefulStop()
 }
 
 //Register a known actor props by name
@@ -69,6 +68,7 @@ func GetKnownKinds() []string {
 }
 
 type activator struct {
+	system *actor.ActorSystem
 }
 
 //ErrActivatorUnavailable : this error will not panic the Activator.
@@ -91,9 +91,22 @@ func ActivatorForAddress(address string) *actor.PID {
 	return pid
 }
 
+//ActivatorForAddress returns a PID for the activator at the given address, resolved by the actor system of r
+func (r *Remote) ActivatorForAddress(address string) *actor.PID {
+	return r.system.NewPID(address, "activator")
+}
+
 //SpawnFuture spawns a remote actor and returns a Future that completes once the actor is started
 func SpawnFuture(address, name, kind string, timeout time.Duration) *actor.Future {
-	activator := ActivatorForAddress(address)
+	return spawnFuture(ActivatorForAddress(address), name, kind, timeout)
+}
+
+//SpawnFuture spawns a remote actor from the actor system of r and returns a Future that completes once the actor is started
+func (r *Remote) SpawnFuture(address, name, kind string, timeout time.Duration) *actor.Future {
+	return spawnFuture(r.ActivatorForAddress(address), name, kind, timeout)
+}
+
+func spawnFuture(activator *actor.PID, name, kind string, timeout time.Duration) *actor.Future {
 	f := activator.RequestFuture(&ActorPidRequest{
 		Name: name,
 		Kind: kind,
@@ -108,7 +121,20 @@ func Spawn(address, kind string, timeout time.Duration) (*ActorPidResponse, erro
 
 //SpawnNamed spawns a named remote actor of a given type at a given address
 func SpawnNamed(address, name, kind string, timeout time.Duration) (*ActorPidResponse, error) {
-	activator := ActivatorForAddress(address)
+	return spawnNamed(ActivatorForAddress(address), name, kind, timeout)
+}
+
+//Spawn spawns a remote actor of a given type at a given address from the actor system of r
+func (r *Remote) Spawn(address, kind string, timeout time.Duration) (*ActorPidResponse, error) {
+	return r.SpawnNamed(address, "", kind, timeout)
+}
+
+//SpawnNamed spawns a named remote actor of a given type at a given address from the actor system of r
+func (r *Remote) SpawnNamed(address, name, kind string, timeout time.Duration) (*ActorPidResponse, error) {
+	return spawnNamed(r.ActivatorForAddress(address), name, kind, timeout)
+}
+
+func spawnNamed(activator *actor.PID, name, kind string, timeout time.Duration) (*ActorPidResponse, error) {
 	res, err := activator.RequestFuture(&ActorPidRequest{
 		Name: name,
 		Kind: kind,
@@ -124,13 +150,13 @@ func SpawnNamed(address, name, kind string, timeout time.Duration) (*ActorPidRes
 	}
 }
 
-func newActivatorActor() actor.Producer {
+func newActivatorActor(system *actor.ActorSystem) actor.Producer {
 	return func() actor.Actor {
-		return &activator{}
+		return &activator{system: system}
 	}
 }
 
-func (*activator) Receive(context actor.Context) {
+func (state *activator) Receive(context actor.Context) {
 	switch msg := context.Message().(type) {
 	case *actor.Started:
 		plog.Debug("Started Activator")
@@ -140,10 +166,10 @@ func (*activator) Receive(context actor.Context) {
 
 		//unnamed actor, assign auto ID
 		if name == "" {
-			name = actor.ProcessRegistry.NextId()
+			name = state.system.ProcessRegistry.NextId()
 		}
 
-		pid, err := actor.SpawnNamed(&props, "Remote$"+name)
+		pid, err := state.system.SpawnNamed(&props, "Remote$"+name)
 
 		if err == nil {
 			response := &ActorPidResponse{Pid: pid}
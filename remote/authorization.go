@@ -0,0 +1,142 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package remote
+
+import (
+	"path"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+)
+
+// AuthorizeFunc decides whether a message received from another node may be delivered to a local actor.
+// peer is the node as authenticated by the Transport, sender the sender PID the node claims, nil when the message
+// has no sender, and typeName the name the message was serialized with, such as "actor.Stop".
+// Only the identity of peer is verified, the sender PID is set by the other node
+type AuthorizeFunc func(peer *Peer, sender *actor.PID, target *actor.PID, typeName string) bool
+
+// DeliveryRejectedEvent is published when a message received from another node is not authorized, it is then dropped
+type DeliveryRejectedEvent struct {
+	Peer     *Peer
+	Sender   *actor.PID
+	Target   *actor.PID
+	TypeName string
+}
+
+type policyRule struct {
+	identity string
+	sender   string
+	target   string
+	typeName string
+	allow    bool
+}
+
+func (rule *policyRule) match(identity string, sender *actor.PID, target *actor.PID, typeName string) bool {
+	return matchIdentity(rule.identity, identity) &&
+		matchSender(rule.sender, sender) &&
+		matchPattern(rule.target, target.Id) &&
+		matchPattern(rule.typeName, typeName)
+}
+
+// matchSender matches the address of a sender with a pattern, "*" matches the messages without sender too
+func matchSender(pattern string, sender *actor.PID) bool {
+	if pattern == "*" {
+		return true
+	}
+	return sender != nil && matchPattern(pattern, sender.Address)
+}
+
+// matchIdentity matches an identity with a pattern, an identity always matches itself as a CURVE key may contain the pattern characters
+func matchIdentity(pattern, identity string) bool {
+	return pattern == identity || matchPattern(pattern, identity)
+}
+
+// matchPattern matches name with a path.Match pattern, "*" matches any name and a malformed pattern matches nothing
+func matchPattern(pattern, name string) bool {
+	if pattern == "*" {
+		return true
+	}
+	ok, err := path.Match(pattern, name)
+	return ok && err == nil
+}
+
+// Policy authorizes the messages received from other nodes with rules matching the verified identity of the node
+// or the address of the sender, the target PID id and the type name with path.Match patterns, such as "*" or
+// "EndpointSupervisor/*". The first matching rule decides. The rules must all be added before the policy is used.
+//
+// A node has an identity only when the transport authenticates it, with mutual TLS or CURVE. Without them every
+// node has the identity "", which identity rules match with the pattern "" or "*", and the sender address is the
+// only way to tell nodes apart. The sender address is claimed by the other node, it only suits trusted networks
+type Policy struct {
+	rules []*policyRule
+	allow bool
+}
+
+// NewPolicy returns a Policy without rules, allow decides the messages no rule matches
+func NewPolicy(allow bool) *Policy {
+	return &Policy{allow: allow}
+}
+
+// Allow adds a rule delivering the matching messages of the nodes with the identity, it returns the policy
+func (p *Policy) Allow(identity, target, typeName string) *Policy {
+	p.rules = append(p.rules, &policyRule{identity: identity, sender: "*", target: target, typeName: typeName, allow: true})
+	return p
+}
+
+// Deny adds a rule dropping the matching messages of the nodes with the identity, it returns the policy
+func (p *Policy) Deny(identity, target, typeName string) *Policy {
+	p.rules = append(p.rules, &policyRule{identity: identity, sender: "*", target: target, typeName: typeName, allow: false})
+	return p
+}
+
+// AllowSender adds a rule delivering the matching messages with a sender at the address, such as "node1:*",
+// it returns the policy. The messages without sender do not match
+func (p *Policy) AllowSender(address, target, typeName string) *Policy {
+	p.rules = append(p.rules, &policyRule{identity: "*", sender: address, target: target, typeName: typeName, allow: true})
+	return p
+}
+
+// DenySender adds a rule dropping the matching messages with a sender at the address, it returns the policy.
+// The messages without sender do not match
+func (p *Policy) DenySender(address, target, typeName string) *Policy {
+	p.rules = append(p.rules, &policyRule{identity: "*", sender: address, target: target, typeName: typeName, allow: false})
+	return p
+}
+
+// Authorize is the AuthorizeFunc of the policy
+func (p *Policy) Authorize(peer *Peer, sender *actor.PID, target *actor.PID, typeName string) bool {
+	for _, rule := range p.rules {
+		if rule.match(peer.Identity, sender, target, typeName) {
+			return rule.allow
+		}
+	}
+	return p.allow
+}
@@ -0,0 +1,170 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package remote
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+	"github.com/OnyxPay/OnyxChain-eventbus/actor/actortest"
+	"github.com/OnyxPay/OnyxChain-eventbus/eventstream"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy_Authorize(t *testing.T) {
+	const key = "rq:rM>}U?@Lns47E1%kR.o@n%FcmmsL/@{H8]yf7"
+	policy := NewPolicy(false).
+		Deny("node1", "EndpointSupervisor/*", "*").
+		Allow("node*", "*", "remote.*").
+		Allow(key, "*", "*")
+	target := actor.NewLocalPID("EndpointSupervisor/1")
+	other := actor.NewLocalPID("other")
+	//the sender PID is set by the other node, only the verified identity is matched
+	sender := actor.NewPID("node2:8080", "sender")
+
+	assert.False(t, policy.Authorize(&Peer{Identity: "node1"}, sender, target, "remote.ActorPidRequest"))
+	assert.True(t, policy.Authorize(&Peer{Identity: "node1"}, sender, other, "remote.ActorPidRequest"))
+	assert.True(t, policy.Authorize(&Peer{Identity: "node2"}, sender, target, "remote.ActorPidRequest"))
+	assert.False(t, policy.Authorize(&Peer{Identity: "node2"}, sender, target, "actor.Stop"))
+	assert.True(t, policy.Authorize(&Peer{Identity: key}, nil, target, "actor.Stop"))
+	assert.False(t, policy.Authorize(&Peer{}, sender, other, "remote.ActorPidRequest"))
+	assert.True(t, NewPolicy(true).Authorize(&Peer{}, nil, other, "actor.Stop"))
+}
+
+func TestPolicy_AuthorizeSender(t *testing.T) {
+	policy := NewPolicy(false).
+		DenySender("*", "EndpointSupervisor/*", "*").
+		AllowSender("node1:*", "*", "*").
+		Allow("", "*", "remote.ActorPidRequest")
+	target := actor.NewLocalPID("EndpointSupervisor/1")
+	other := actor.NewLocalPID("other")
+
+	//without TLS or CURVE the peers have no identity, the sender address tells them apart
+	peer := &Peer{}
+	assert.True(t, policy.Authorize(peer, actor.NewPID("node1:8080", "sender"), other, "actor.Stop"))
+	assert.False(t, policy.Authorize(peer, actor.NewPID("node1:8080", "sender"), target, "actor.Stop"))
+	assert.False(t, policy.Authorize(peer, actor.NewPID("node2:8080", "sender"), other, "actor.Stop"))
+	assert.False(t, policy.Authorize(peer, nil, other, "actor.Stop"))
+	//an identity rule for "" matches every peer without identity
+	assert.True(t, policy.Authorize(peer, nil, other, "remote.ActorPidRequest"))
+	assert.True(t, policy.Authorize(peer, actor.NewPID("node2:8080", "sender"), other, "remote.ActorPidRequest"))
+	assert.False(t, policy.Authorize(&Peer{Identity: "node2"}, nil, other, "remote.ActorPidRequest"))
+}
+
+func newTestReader(system *actor.ActorSystem, stream *eventstream.EventStream, authorize AuthorizeFunc) *endpointReader {
+	return &endpointReader{
+		remote: &Remote{
+			system: system,
+			config: &remoteConfig{eventStream: stream, authorize: authorize},
+		},
+	}
+}
+
+func newTestBatch(t *testing.T, target *actor.PID, sender *actor.PID, message interface{}) *MessageBatch {
+	data, typeName, err := Serialize(message, DefaultSerializerID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &MessageBatch{
+		TypeNames:   []string{typeName},
+		TargetNames: []string{target.Id},
+		Envelopes: []*MessageEnvelope{{
+			MessageData:  data,
+			Sender:       sender,
+			SerializerId: DefaultSerializerID,
+		}},
+	}
+}
+
+func TestEndpointReader_Authorized(t *testing.T) {
+	system := actor.NewSystem(actor.Config{})
+	defer system.Shutdown()
+	probe := actortest.NewTestProbe(t, system)
+	defer probe.Stop()
+	stream := &eventstream.EventStream{}
+	var rejected []interface{}
+	stream.Subscribe(func(evt interface{}) {
+		rejected = append(rejected, evt)
+	})
+	reader := newTestReader(system, stream, NewPolicy(false).Allow("node1", "*", "*").Authorize)
+
+	message := &ActorPidRequest{Name: "allowed"}
+	assert.NoError(t, reader.ReceiveBatch(&Peer{Identity: "node1"}, newTestBatch(t, probe.PID(), nil, message)))
+	probe.ExpectMsg(message, time.Second)
+	assert.Empty(t, rejected)
+}
+
+func TestEndpointReader_AuthorizedSender(t *testing.T) {
+	system := actor.NewSystem(actor.Config{})
+	defer system.Shutdown()
+	probe := actortest.NewTestProbe(t, system)
+	defer probe.Stop()
+	stream := &eventstream.EventStream{}
+	var rejected []interface{}
+	stream.Subscribe(func(evt interface{}) {
+		rejected = append(rejected, evt)
+	})
+	reader := newTestReader(system, stream, NewPolicy(false).AllowSender("node1:*", "*", "*").Authorize)
+
+	message := &ActorPidRequest{Name: "allowed"}
+	assert.NoError(t, reader.ReceiveBatch(&Peer{}, newTestBatch(t, probe.PID(), actor.NewPID("node1:8080", "sender"), message)))
+	probe.ExpectMsg(message, time.Second)
+	assert.NoError(t, reader.ReceiveBatch(&Peer{}, newTestBatch(t, probe.PID(), actor.NewPID("node2:8080", "sender"), message)))
+	probe.ExpectNoMsg(100 * time.Millisecond)
+	assert.Len(t, rejected, 1)
+}
+
+func TestEndpointReader_Rejected(t *testing.T) {
+	system := actor.NewSystem(actor.Config{})
+	defer system.Shutdown()
+	probe := actortest.NewTestProbe(t, system)
+	defer probe.Stop()
+	stream := &eventstream.EventStream{}
+	var rejected []interface{}
+	stream.Subscribe(func(evt interface{}) {
+		rejected = append(rejected, evt)
+	})
+	reader := newTestReader(system, stream, NewPolicy(false).Allow("node1", "*", "*").Authorize)
+
+	//a sender PID claiming to be on the allowed node does not authorize the message
+	peer := &Peer{Identity: "node2"}
+	sender := actor.NewPID("node1:8080", "sender")
+	assert.NoError(t, reader.ReceiveBatch(peer, newTestBatch(t, probe.PID(), sender, &ActorPidRequest{Name: "rejected"})))
+	probe.ExpectNoMsg(100 * time.Millisecond)
+	assert.Equal(t, []interface{}{&DeliveryRejectedEvent{
+		Peer:     peer,
+		Sender:   sender,
+		Target:   system.NewLocalPID(probe.PID().Id),
+		TypeName: "remote.ActorPidRequest",
+	}}, rejected)
+}
@@ -31,18 +31,30 @@ limitations under the License.
 *****************************************************/
 package remote
 
-import "google.golang.org/grpc"
+import (
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/eventhub"
+	"github.com/OnyxPay/OnyxChain-eventbus/eventstream"
+	"google.golang.org/grpc"
+)
 
 //RemotingOption configures how the remote infrastructure is started
 type RemotingOption func(*remoteConfig)
 
 func defaultRemoteConfig() *remoteConfig {
 	return &remoteConfig{
-		dialOptions:              []grpc.DialOption{grpc.WithInsecure()},
-		endpointWriterBatchSize:  1,
-		endpointManagerBatchSize: 1,
-		endpointWriterQueueSize:  1000000,
-		endpointManagerQueueSize: 1000000,
+		endpointWriterBatchSize:      1,
+		endpointManagerBatchSize:     1,
+		endpointWriterQueueSize:      1000000,
+		endpointManagerQueueSize:     1000000,
+		endpointWriterBufferSize:     10000,
+		endpointWriterMaxRetries:     5,
+		endpointWriterInitialBackoff: 200 * time.Millisecond,
+		endpointWriterMaxBackoff:     5 * time.Second,
+		heartbeatInterval:            time.Second,
+		suspectTimeout:               3 * time.Second,
+		unreachableTimeout:           10 * time.Second,
 	}
 }
 
@@ -58,6 +70,42 @@ func WithEndpointWriterQueueSize(queueSize int) RemotingOption {
 	}
 }
 
+// WithEndpointWriterBufferSize sets how many messages an endpoint writer keeps while it reconnects,
+// the messages over it are published as dead letters
+func WithEndpointWriterBufferSize(bufferSize int) RemotingOption {
+	return func(config *remoteConfig) {
+		config.endpointWriterBufferSize = bufferSize
+	}
+}
+
+// WithEndpointWriterMaxRetries sets how many connection attempts in a row may fail before the endpoint is terminated
+func WithEndpointWriterMaxRetries(maxRetries int) RemotingOption {
+	return func(config *remoteConfig) {
+		config.endpointWriterMaxRetries = maxRetries
+	}
+}
+
+// WithEndpointWriterBackoff sets the delay before reconnecting after a failed attempt,
+// it doubles with each failure from initial up to max
+func WithEndpointWriterBackoff(initial, max time.Duration) RemotingOption {
+	return func(config *remoteConfig) {
+		config.endpointWriterInitialBackoff = initial
+		config.endpointWriterMaxBackoff = max
+	}
+}
+
+// WithHeartbeat sets how often the endpoint writers send heartbeats, a node is suspected when it answered none
+// for suspectTimeout and unreachable after unreachableTimeout. A zero interval disables the heartbeats.
+// By default a heartbeat is sent every second, a node is suspected after 3 seconds and unreachable after 10.
+// The nodes of older versions do not answer heartbeats, disable them while such nodes are part of the cluster
+func WithHeartbeat(interval, suspectTimeout, unreachableTimeout time.Duration) RemotingOption {
+	return func(config *remoteConfig) {
+		config.heartbeatInterval = interval
+		config.suspectTimeout = suspectTimeout
+		config.unreachableTimeout = unreachableTimeout
+	}
+}
+
 func WithEndpointManagerBatchSize(batchSize int) RemotingOption {
 	return func(config *remoteConfig) {
 		config.endpointManagerBatchSize = batchSize
@@ -70,6 +118,28 @@ func WithEndpointManagerQueueSize(queueSize int) RemotingOption {
 	}
 }
 
+// WithTLS secures the gRPC transport with mutual TLS, see remoteconfig.WithTLS for the paths of common/config.
+// The node presents the PEM certificate and key in certFile and keyFile, and only connects with the nodes
+// whose certificates are signed by the certificate authority in caFile. Without files the connections are in cleartext,
+// StartSystem returns an error if only some are set
+func WithTLS(certFile, keyFile, caFile string) RemotingOption {
+	return func(config *remoteConfig) {
+		config.certFile = certFile
+		config.keyFile = keyFile
+		config.caFile = caFile
+	}
+}
+
+// WithAuthorizer makes the node deliver only the messages from other nodes authorize accepts, such as Policy.Authorize.
+// The nodes are identified by the Transport, by their certificates with WithTLS or their keys with CURVE,
+// without them the nodes have no identity and only the sender address they claim tells them apart.
+// The rejected messages are dropped and published as a DeliveryRejectedEvent. All messages are delivered by default
+func WithAuthorizer(authorize AuthorizeFunc) RemotingOption {
+	return func(config *remoteConfig) {
+		config.authorize = authorize
+	}
+}
+
 func WithDialOptions(options ...grpc.DialOption) RemotingOption {
 	return func(config *remoteConfig) {
 		config.dialOptions = options
@@ -88,12 +158,51 @@ func WithCallOptions(options ...grpc.CallOption) RemotingOption {
 	}
 }
 
+// WithEventStream sets the EventStream endpoint events are published on, it defaults to the one of the actor system
+func WithEventStream(stream *eventstream.EventStream) RemotingOption {
+	return func(config *remoteConfig) {
+		config.eventStream = stream
+	}
+}
+
+// WithTransport sets the Transport carrying the messages between the nodes, it defaults to gRPC.
+// The gRPC dial, server and call options only apply to the default transport
+func WithTransport(transport Transport) RemotingOption {
+	return func(config *remoteConfig) {
+		config.transport = transport
+	}
+}
+
+// WithEventHubs sets the EventHubs told about connected and terminated nodes, so that their cluster peers exchange
+// subscriptions with the nodes. It defaults to eventhub.GlobalEventHub for the default actor system and to none for the others
+func WithEventHubs(hubs ...*eventhub.EventHub) RemotingOption {
+	return func(config *remoteConfig) {
+		config.eventHubs = hubs
+		config.eventHubsSet = true
+	}
+}
+
 type remoteConfig struct {
-	serverOptions            []grpc.ServerOption
-	callOptions              []grpc.CallOption
-	dialOptions              []grpc.DialOption
-	endpointWriterBatchSize  int
-	endpointWriterQueueSize  int
-	endpointManagerBatchSize int
-	endpointManagerQueueSize int
+	transport                    Transport
+	serverOptions                []grpc.ServerOption
+	callOptions                  []grpc.CallOption
+	dialOptions                  []grpc.DialOption
+	endpointWriterBatchSize      int
+	endpointWriterQueueSize      int
+	endpointWriterBufferSize     int
+	endpointWriterMaxRetries     int
+	endpointWriterInitialBackoff time.Duration
+	endpointWriterMaxBackoff     time.Duration
+	authorize                    AuthorizeFunc
+	certFile                     string
+	keyFile                      string
+	caFile                       string
+	heartbeatInterval            time.Duration
+	suspectTimeout               time.Duration
+	unreachableTimeout           time.Duration
+	endpointManagerBatchSize     int
+	endpointManagerQueueSize     int
+	eventStream                  *eventstream.EventStream
+	eventHubs                    []*eventhub.EventHub
+	eventHubsSet                 bool
 }
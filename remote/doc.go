@@ -32,6 +32,7 @@ limitations under the License.
 /*
 Package remote provides access to actors across a network or other I/O connection.
 
+The messages are carried by a Transport, gRPC by default, chosen with WithTransport.
 
 */
 package remote
@@ -34,61 +34,89 @@ package remote
 import (
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/OnyxPay/OnyxChain-eventbus/actor"
 	"github.com/OnyxPay/OnyxChain-eventbus/eventstream"
 	"github.com/OnyxPay/OnyxChain-eventbus/mailbox"
 )
 
-var endpointManager *endpointManagerValue
-
+// endpointLazy is the endpoint of an address, created by the endpoint supervisor on first use
 type endpointLazy struct {
-	valueFunc func() *endpoint
-	unloaded  uint32
+	once     sync.Once
+	value    *endpoint
+	unloaded uint32
+}
+
+func (el *endpointLazy) get(em *endpointManagerValue, address string) *endpoint {
+	el.once.Do(func() {
+		rst, _ := em.endpointSupervisor.RequestFuture(address, -1).Result()
+		el.value = rst.(*endpoint)
+	})
+	return el.value
 }
 
 type endpoint struct {
-	writer  *actor.PID
-	watcher *actor.PID
+	writer   *actor.PID
+	watcher  *actor.PID
+	counters *endpointCounters
+}
+
+// endpointCounters counts the messages to an address an endpoint writer did not send yet, and those it dropped
+type endpointCounters struct {
+	address string
+	queued  int64
+	dropped int64
 }
 
+// drainInterval is how often a graceful shutdown checks whether the endpoint writers sent their messages
+const drainInterval = 10 * time.Millisecond
+
 type endpointManagerValue struct {
 	connections        *sync.Map
+	counters           *sync.Map // the counters of the current endpoints by address
 	config             *remoteConfig
 	endpointSupervisor *actor.PID
-	endpointSub        *eventstream.Subscription
+	endpointSubs       []*eventstream.Subscription
+
+	removedMu sync.Mutex
+	// removed are the counters of the removed endpoints whose writers may still drop messages,
+	// removedDropped the messages the others dropped by address
+	removed        []*endpointCounters
+	removedDropped map[string]int64
 }
 
-func startEndpointManager(config *remoteConfig) {
+func (r *Remote) startEndpointManager() {
 	plog.Debug("Started EndpointManager")
 
-	props := actor.FromProducer(newEndpointSupervisor).
+	props := actor.FromProducer(newEndpointSupervisor(r)).
 		WithGuardian(actor.RestartingSupervisorStrategy()).
 		WithSupervisor(actor.RestartingSupervisorStrategy()).
 		WithDispatcher(mailbox.NewSynchronizedDispatcher(300))
-	endpointSupervisor, _ := actor.SpawnNamed(props, "EndpointSupervisor")
+	endpointSupervisor, _ := r.system.SpawnNamed(props, "EndpointSupervisor")
 
-	endpointManager = &endpointManagerValue{
+	endpointManager := &endpointManagerValue{
 		connections:        &sync.Map{},
-		config:             config,
+		counters:           &sync.Map{},
+		config:             r.config,
 		endpointSupervisor: endpointSupervisor,
+		removedDropped:     make(map[string]int64),
 	}
 
-	endpointManager.endpointSub = eventstream.
-		Subscribe(endpointManager.endpointEvent).
-		WithPredicate(func(m interface{}) bool {
-			switch m.(type) {
-			case *EndpointTerminatedEvent, *EndpointConnectedEvent:
-				return true
-			}
-			return false
-		})
+	endpointManager.endpointSubs = []*eventstream.Subscription{
+		r.config.eventStream.SubscribeType((*EndpointTerminatedEvent)(nil), endpointManager.endpointEvent),
+		r.config.eventStream.SubscribeType((*EndpointConnectedEvent)(nil), endpointManager.endpointEvent),
+	}
+	r.endpointManager = endpointManager
 }
 
-func stopEndpointManager() {
-	eventstream.Unsubscribe(endpointManager.endpointSub)
+func (r *Remote) stopEndpointManager() {
+	endpointManager := r.endpointManager
+	for _, sub := range endpointManager.endpointSubs {
+		eventstream.Unsubscribe(sub)
+	}
 	endpointManager.endpointSupervisor.GracefulStop()
-	endpointManager.endpointSub = nil
+	endpointManager.endpointSubs = nil
 	endpointManager.connections = nil
 	plog.Debug("Stopped EndpointManager")
 }
@@ -124,29 +152,103 @@ func (em *endpointManagerValue) remoteUnwatch(msg *remoteUnwatch) {
 func (em *endpointManagerValue) remoteDeliver(msg *remoteDeliver) {
 	address := msg.target.Address
 	endpoint := em.ensureConnected(address)
+	atomic.AddInt64(&endpoint.counters.queued, 1)
 	endpoint.writer.Tell(msg)
 }
 
+// newCounters returns the counters of a new endpoint to address
+func (em *endpointManagerValue) newCounters(address string) *endpointCounters {
+	c := &endpointCounters{address: address}
+	em.counters.Store(address, c)
+	return c
+}
+
+// removeCounters keeps the counters of a removed endpoint until its writer stopped dropping messages
+func (em *endpointManagerValue) removeCounters(c *endpointCounters) {
+	em.counters.Delete(c.address)
+	em.removedMu.Lock()
+	defer em.removedMu.Unlock()
+	removed := em.removed[:0]
+	for _, r := range append(em.removed, c) {
+		if atomic.LoadInt64(&r.queued) > 0 {
+			removed = append(removed, r)
+		} else {
+			em.removedDropped[r.address] += atomic.LoadInt64(&r.dropped)
+		}
+	}
+	em.removed = removed
+}
+
+// count returns by address the messages not sent yet by the current endpoints,
+// and the messages dropped or left unsent by all the endpoints
+func (em *endpointManagerValue) count() (queued map[string]int64, dropped map[string]int64) {
+	queued = make(map[string]int64)
+	dropped = make(map[string]int64)
+	em.counters.Range(func(k, v interface{}) bool {
+		c := v.(*endpointCounters)
+		queued[c.address] += atomic.LoadInt64(&c.queued)
+		dropped[c.address] += atomic.LoadInt64(&c.dropped)
+		return true
+	})
+	em.removedMu.Lock()
+	defer em.removedMu.Unlock()
+	for _, c := range em.removed {
+		//the messages a removed endpoint did not send are dropped
+		dropped[c.address] += atomic.LoadInt64(&c.queued) + atomic.LoadInt64(&c.dropped)
+	}
+	for address, n := range em.removedDropped {
+		dropped[address] += n
+	}
+	return queued, dropped
+}
+
+// dropped returns the messages dropped so far by address
+func (em *endpointManagerValue) dropped() map[string]int64 {
+	_, dropped := em.count()
+	return dropped
+}
+
+// undelivered returns by address the messages not sent yet and those dropped since the since counts
+func (em *endpointManagerValue) undelivered(since map[string]int64) map[string]int {
+	queued, dropped := em.count()
+	undelivered := make(map[string]int)
+	for address, n := range dropped {
+		queued[address] += n - since[address]
+	}
+	for address, n := range queued {
+		if n > 0 {
+			undelivered[address] = int(n)
+		}
+	}
+	return undelivered
+}
+
+// drain waits until the current endpoint writers sent all their messages, or the timeout elapsed on clock
+func (em *endpointManagerValue) drain(clock actor.Clock, timeout time.Duration) {
+	deadline := clock.Now().Add(timeout)
+	for {
+		queued := int64(0)
+		em.counters.Range(func(k, v interface{}) bool {
+			queued += atomic.LoadInt64(&v.(*endpointCounters).queued)
+			return true
+		})
+		if queued == 0 || !clock.Now().Before(deadline) {
+			return
+		}
+		tick := make(chan struct{})
+		clock.AfterFunc(drainInterval, func() {
+			close(tick)
+		})
+		<-tick
+	}
+}
+
 func (em *endpointManagerValue) ensureConnected(address string) *endpoint {
 	e, ok := em.connections.Load(address)
 	if !ok {
-		el := &endpointLazy{}
-		var once sync.Once
-		el.valueFunc = func() *endpoint {
-			once.Do(func() {
-				rst, _ := em.endpointSupervisor.RequestFuture(address, -1).Result()
-				ep := rst.(*endpoint)
-				el.valueFunc = func() *endpoint {
-					return ep
-				}
-			})
-			return el.valueFunc()
-		}
-		e, _ = em.connections.LoadOrStore(address, el)
+		e, _ = em.connections.LoadOrStore(address, &endpointLazy{})
 	}
-
-	el := e.(*endpointLazy)
-	return el.valueFunc()
+	return e.(*endpointLazy).get(em, address)
 }
 
 func (em *endpointManagerValue) removeEndpoint(msg *EndpointTerminatedEvent) {
@@ -154,8 +256,10 @@ func (em *endpointManagerValue) removeEndpoint(msg *EndpointTerminatedEvent) {
 	if ok {
 		le := v.(*endpointLazy)
 		if atomic.CompareAndSwapUint32(&le.unloaded, 0, 1) {
+			ep := le.get(em, msg.Address)
+			//the next endpoint to the address starts counting from zero
+			em.removeCounters(ep.counters)
 			em.connections.Delete(msg.Address)
-			ep := le.valueFunc()
 			ep.watcher.Tell(msg)
 			ep.watcher.Stop()
 			ep.writer.Stop()
@@ -163,17 +267,23 @@ func (em *endpointManagerValue) removeEndpoint(msg *EndpointTerminatedEvent) {
 	}
 }
 
-type endpointSupervisor struct{}
+type endpointSupervisor struct {
+	remote *Remote
+}
 
-func newEndpointSupervisor() actor.Actor {
-	return &endpointSupervisor{}
+func newEndpointSupervisor(remote *Remote) actor.Producer {
+	return func() actor.Actor {
+		return &endpointSupervisor{remote: remote}
+	}
 }
 
 func (state *endpointSupervisor) Receive(ctx actor.Context) {
 	if address, ok := ctx.Message().(string); ok {
+		counters := state.remote.endpointManager.newCounters(address)
 		e := &endpoint{
-			writer:  state.spawnEndpointWriter(address, ctx),
-			watcher: state.spawnEndpointWatcher(address, ctx),
+			writer:   state.spawnEndpointWriter(address, counters, ctx),
+			watcher:  state.spawnEndpointWatcher(address, ctx),
+			counters: counters,
 		}
 		ctx.Respond(e)
 	}
@@ -183,17 +293,17 @@ func (state *endpointSupervisor) HandleFailure(supervisor actor.Supervisor, chil
 	supervisor.RestartChildren(child)
 }
 
-func (state *endpointSupervisor) spawnEndpointWriter(address string, ctx actor.Context) *actor.PID {
+func (state *endpointSupervisor) spawnEndpointWriter(address string, counters *endpointCounters, ctx actor.Context) *actor.PID {
 	props := actor.
-		FromProducer(newEndpointWriter(address, endpointManager.config)).
-		WithMailbox(newEndpointWriterMailbox(endpointManager.config.endpointWriterBatchSize, endpointManager.config.endpointWriterQueueSize))
+		FromProducer(newEndpointWriter(address, state.remote.config, counters)).
+		WithMailbox(newEndpointWriterMailbox(state.remote.config.endpointWriterBatchSize, state.remote.config.endpointWriterQueueSize))
 	pid := ctx.Spawn(props)
 	return pid
 }
 
 func (state *endpointSupervisor) spawnEndpointWatcher(address string, ctx actor.Context) *actor.PID {
 	props := actor.
-		FromProducer(newEndpointWatcher(address))
+		FromProducer(newEndpointWatcher(address, state.remote))
 	pid := ctx.Spawn(props)
 	return pid
 }
@@ -0,0 +1,82 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package remote
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpointManager_EnsureConnected(t *testing.T) {
+	transport := newTestTransport()
+	r, _, stop := startTestRemote(t, transport)
+	defer stop()
+
+	endpoints := make([]*endpoint, 20)
+	var wg sync.WaitGroup
+	for i := range endpoints {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			endpoints[i] = r.endpointManager.ensureConnected("node2:8080")
+		}(i)
+	}
+	wg.Wait()
+	for _, e := range endpoints {
+		assert.True(t, e == endpoints[0], "expected a single endpoint")
+	}
+	transport.expectDial(t)
+	transport.expectNoDial(t)
+}
+
+func TestEndpointManager_RemoveEndpoint(t *testing.T) {
+	transport := newTestTransport()
+	r, _, stop := startTestRemote(t, transport)
+	defer stop()
+	em := r.endpointManager
+
+	removed := em.ensureConnected("node2:8080")
+	transport.expectDial(t)
+	//a message told to the writer as it stops is never sent
+	atomic.AddInt64(&removed.counters.queued, 1)
+	em.removeEndpoint(&EndpointTerminatedEvent{Address: "node2:8080"})
+
+	e := em.ensureConnected("node2:8080")
+	transport.expectDial(t)
+	assert.False(t, e == removed, "expected a new endpoint")
+	assert.False(t, e.counters == removed.counters, "expected new counters")
+	assert.Equal(t, map[string]int64{"node2:8080": 1}, em.dropped())
+	assert.Equal(t, map[string]int{}, em.undelivered(em.dropped()))
+}
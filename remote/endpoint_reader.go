@@ -32,87 +32,164 @@ limitations under the License.
 package remote
 
 import (
-	"time"
+	"errors"
+	"reflect"
+	"sync/atomic"
 
 	"github.com/OnyxPay/OnyxChain-eventbus/actor"
 	"github.com/OnyxPay/OnyxChain-eventbus/log"
-	"golang.org/x/net/context"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
+var (
+	errSuspended = errors.New("remote: endpoint reader suspended")
+	pidType      = reflect.TypeOf((*actor.PID)(nil))
+)
+
+// endpointReader is the BatchReceiver of a Remote, it delivers the received messages to the local actors
 type endpointReader struct {
-	suspended bool
+	suspended int32
+	remote    *Remote
+}
+
+func (s *endpointReader) Accepting() bool {
+	return atomic.LoadInt32(&s.suspended) == 0
 }
 
-func (s *endpointReader) Connect(ctx context.Context, req *ConnectRequest) (*ConnectResponse, error) {
-	if s.suspended {
-		return nil, status.Error(codes.Canceled, "Suspended")
+func (s *endpointReader) ReceiveBatch(peer *Peer, batch *MessageBatch) error {
+	if !s.Accepting() {
+		return errSuspended
 	}
 
-	return &ConnectResponse{DefaultSerializerId: DefaultSerializerID}, nil
-}
+	targets := make([]*actor.PID, len(batch.TargetNames))
+	for i := 0; i < len(batch.TargetNames); i++ {
+		targets[i] = s.remote.system.NewLocalPID(batch.TargetNames[i])
+	}
 
-func (s *endpointReader) Receive(stream Remoting_ReceiveServer) error {
-	targets := make([]*actor.PID, 100)
-	for {
-		if s.suspended {
-			time.Sleep(time.Millisecond * 500)
+	for _, envelope := range batch.Envelopes {
+		pid := targets[envelope.Target]
+		typeName := batch.TypeNames[envelope.TypeId]
+		if !s.authorized(peer, envelope.Sender, pid, typeName) {
 			continue
 		}
-
-		batch, err := stream.Recv()
+		message, err := Deserialize(envelope.MessageData, typeName, envelope.SerializerId)
 		if err != nil {
-			plog.Debug("EndpointReader failed to read", log.Error(err))
+			plog.Debug("EndpointReader failed to deserialize", log.Error(err))
 			return err
 		}
-
-		//only grow pid lookup if needed
-		if len(batch.TargetNames) > len(targets) {
-			targets = make([]*actor.PID, len(batch.TargetNames))
+		//the PIDs in the message are resolved by the remote too, messages to them go through its transport
+		bindPIDs(s.remote.system, reflect.ValueOf(message))
+		//if message is system message send it as sysmsg instead of usermsg
+
+		sender := envelope.Sender
+		if sender != nil {
+			//resolve the sender in the actor system of the remote, replies then go back through it
+			sender = s.remote.system.NewPID(sender.Address, sender.Id)
 		}
 
-		for i := 0; i < len(batch.TargetNames); i++ {
-			targets[i] = actor.NewLocalPID(batch.TargetNames[i])
+		switch msg := message.(type) {
+		case *actor.Terminated:
+			rt := &remoteTerminate{
+				Watchee: msg.Who,
+				Watcher: pid,
+			}
+			s.remote.endpointManager.remoteTerminate(rt)
+		case actor.SystemMessage:
+			ref, _ := s.remote.system.ProcessRegistry.GetLocal(pid.Id)
+			ref.SendSystemMessage(pid, msg)
+		default:
+			var header map[string]string
+			if envelope.MessageHeader != nil {
+				header = envelope.MessageHeader.HeaderData
+			}
+			localEnvelope := &actor.MessageEnvelope{
+				Header:  header,
+				Message: message,
+				Sender:  sender,
+			}
+			pid.Tell(localEnvelope)
 		}
+	}
+	return nil
+}
+
+// authorized checks a message against the authorizer of the remote, a rejected message is reported as an event
+func (s *endpointReader) authorized(peer *Peer, sender *actor.PID, target *actor.PID, typeName string) bool {
+	authorize := s.remote.config.authorize
+	if authorize == nil {
+		return true
+	}
+	if authorize(peer, sender, target, typeName) {
+		return true
+	}
+
+	plog.Debug("EndpointReader rejected message", log.String("peer", peer.Identity), log.Stringer("sender", sender), log.String("target", target.Id), log.String("type", typeName))
+	s.remote.config.eventStream.Publish(&DeliveryRejectedEvent{
+		Peer:     peer,
+		Sender:   sender,
+		Target:   target,
+		TypeName: typeName,
+	})
+	return false
+}
 
-		for _, envelope := range batch.Envelopes {
-			pid := targets[envelope.Target]
-			message, err := Deserialize(envelope.MessageData, batch.TypeNames[envelope.TypeId], envelope.SerializerId)
-			if err != nil {
-				plog.Debug("EndpointReader failed to deserialize", log.Error(err))
-				return err
+// bindPIDs binds the PIDs held by a deserialized message to the system
+func bindPIDs(system *actor.ActorSystem, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		if v.Type() == pidType {
+			system.Bind(v.Interface().(*actor.PID))
+			return
+		}
+		bindPIDs(system, v.Elem())
+	case reflect.Interface:
+		if !v.IsNil() {
+			bindPIDs(system, v.Elem())
+		}
+	case reflect.Struct:
+		if v.Type() == pidType.Elem() {
+			if v.CanAddr() {
+				system.Bind(v.Addr().Interface().(*actor.PID))
 			}
-			//if message is system message send it as sysmsg instead of usermsg
-
-			sender := envelope.Sender
-
-			switch msg := message.(type) {
-			case *actor.Terminated:
-				rt := &remoteTerminate{
-					Watchee: msg.Who,
-					Watcher: pid,
-				}
-				endpointManager.remoteTerminate(rt)
-			case actor.SystemMessage:
-				ref, _ := actor.ProcessRegistry.GetLocal(pid.Id)
-				ref.SendSystemMessage(pid, msg)
-			default:
-				var header map[string]string
-				if envelope.MessageHeader != nil {
-					header = envelope.MessageHeader.HeaderData
-				}
-				localEnvelope := &actor.MessageEnvelope{
-					Header:  header,
-					Message: message,
-					Sender:  sender,
-				}
-				pid.Tell(localEnvelope)
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				bindPIDs(system, v.Field(i))
 			}
 		}
+	case reflect.Slice, reflect.Array:
+		if !mayHoldPID(v.Type().Elem()) {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			bindPIDs(system, v.Index(i))
+		}
+	case reflect.Map:
+		if !mayHoldPID(v.Type().Elem()) {
+			return
+		}
+		for _, key := range v.MapKeys() {
+			bindPIDs(system, v.MapIndex(key))
+		}
 	}
 }
 
+// mayHoldPID reports whether values of the type can hold a PID
+func mayHoldPID(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
 func (s *endpointReader) suspend(toSuspend bool) {
-	s.suspended = toSuspend
+	var suspended int32
+	if toSuspend {
+		suspended = 1
+	}
+	atomic.StoreInt32(&s.suspended, suspended)
 }
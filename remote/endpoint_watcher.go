@@ -33,20 +33,21 @@ package remote
 
 import (
 	"github.com/OnyxPay/OnyxChain-eventbus/actor"
-	"github.com/OnyxPay/OnyxChain-eventbus/eventhub"
 	"github.com/OnyxPay/OnyxChain-eventbus/log"
 )
 
-func newEndpointWatcher(address string) actor.Producer {
+func newEndpointWatcher(address string, remote *Remote) actor.Producer {
 	return func() actor.Actor {
 		return &endpointWatcher{
 			address: address,
+			remote:  remote,
 		}
 	}
 }
 
 type endpointWatcher struct {
 	address string
+	remote  *Remote
 	watched map[string]*actor.PIDSet //key is the watching PID string, value is the watched PID
 }
 
@@ -73,28 +74,35 @@ func (state *endpointWatcher) Receive(ctx actor.Context) {
 			Who:               msg.Watchee,
 			AddressTerminated: false,
 		}
-		ref, ok := actor.ProcessRegistry.GetLocal(msg.Watcher.Id)
+		ref, ok := state.remote.system.ProcessRegistry.GetLocal(msg.Watcher.Id)
 		if ok {
 			ref.SendSystemMessage(msg.Watcher, terminated)
 		}
 	case *EndpointConnectedEvent:
-		//Already connected, pass
+		//Already connected, only let the event hub exchange subscriptions with the node
+		for _, hub := range state.remote.eventHubs {
+			hub.NodeConnected(state.address)
+		}
 	case *EndpointTerminatedEvent:
 		plog.Info("EndpointWatcher handling terminated", log.String("address", state.address))
 
+		//subscribers on the terminated endpoint can no longer receive events
+		for _, hub := range state.remote.eventHubs {
+			hub.RemoveAddress(state.address)
+		}
+
 		for id, pidSet := range state.watched {
 			//try to find the watcher ID in the local actor registry
-			ref, ok := actor.ProcessRegistry.GetLocal(id)
+			ref, ok := state.remote.system.ProcessRegistry.GetLocal(id)
 			if ok {
 				pidSet.ForEach(func(i int, pid actor.PID) {
-					eventhub.GlobalEventHub.RemovePID(pid)
 					//create a terminated event for the Watched actor
 					terminated := &actor.Terminated{
 						Who:               &pid,
 						AddressTerminated: true,
 					}
 
-					watcher := actor.NewLocalPID(id)
+					watcher := state.remote.system.NewLocalPID(id)
 					//send the address Terminated event to the Watcher
 					ref.SendSystemMessage(watcher, terminated)
 				})
@@ -119,7 +127,7 @@ func (state *endpointWatcher) Receive(ctx actor.Context) {
 		}
 
 		//pass it off to the remote PID
-		SendMessage(msg.Watchee, nil, w, nil, -1)
+		state.remote.SendMessage(msg.Watchee, nil, w, nil, -1)
 
 	case *remoteUnwatch:
 		//delete the watch entries
@@ -136,7 +144,7 @@ func (state *endpointWatcher) Receive(ctx actor.Context) {
 		}
 
 		//pass it off to the remote PID
-		SendMessage(msg.Watchee, nil, uw, nil, -1)
+		state.remote.SendMessage(msg.Watchee, nil, uw, nil, -1)
 	case actor.SystemMessage, actor.AutoReceiveMessage:
 		//ignore
 	default:
@@ -148,7 +156,7 @@ func (state *endpointWatcher) Terminated(ctx actor.Context) {
 	switch msg := ctx.Message().(type) {
 	case *remoteWatch:
 		//try to find the watcher ID in the local actor registry
-		ref, ok := actor.ProcessRegistry.GetLocal(msg.Watcher.Id)
+		ref, ok := state.remote.system.ProcessRegistry.GetLocal(msg.Watcher.Id)
 		if ok {
 
 			//create a terminated event for the Watched actor
@@ -161,6 +169,9 @@ func (state *endpointWatcher) Terminated(ctx actor.Context) {
 		}
 	case *EndpointConnectedEvent:
 		plog.Info("EndpointWatcher handling restart", log.String("address", state.address))
+		for _, hub := range state.remote.eventHubs {
+			hub.NodeConnected(state.address)
+		}
 		ctx.SetBehavior(state.Receive)
 	case *remoteTerminate, *EndpointTerminatedEvent, *remoteUnwatch:
 		// pass
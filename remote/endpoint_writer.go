@@ -32,85 +32,268 @@ limitations under the License.
 package remote
 
 import (
+	"errors"
+	"math/rand"
+	"sync/atomic"
 	"time"
 
 	"github.com/OnyxPay/OnyxChain-eventbus/actor"
-	"github.com/OnyxPay/OnyxChain-eventbus/eventstream"
 	"github.com/OnyxPay/OnyxChain-eventbus/log"
-	"golang.org/x/net/context"
-	"google.golang.org/grpc"
 )
 
-func newEndpointWriter(address string, config *remoteConfig) actor.Producer {
+func newEndpointWriter(address string, config *remoteConfig, counters *endpointCounters) actor.Producer {
 	return func() actor.Actor {
 		return &endpointWriter{
-			address: address,
-			config:  config,
+			address:  address,
+			config:   config,
+			counters: counters,
 		}
 	}
 }
 
+var (
+	errConnectionLost = errors.New("remote: connection lost")
+	errUnreachable    = errors.New("remote: node unreachable")
+)
+
+// endpointReconnect makes the endpoint writer try to connect again
+type endpointReconnect struct{}
+
+// endpointHeartbeat makes the endpoint writer send a heartbeat
+type endpointHeartbeat struct{}
+
+// endpointLost tells the endpoint writer its connection is lost
+type endpointLost struct {
+	conn Connection
+}
+
+// endpointWriter sends the messages to an address. While it is disconnected it buffers them and reconnects
+// with an exponential backoff, the endpoint is terminated once the connection attempts failed too many times
 type endpointWriter struct {
 	config              *remoteConfig
 	address             string
-	conn                *grpc.ClientConn
-	stream              Remoting_ReceiveClient
+	counters            *endpointCounters
+	conn                Connection
+	closed              chan struct{}
 	defaultSerializerId int32
+	buffer              []*remoteDeliver
+	failures            int // connection attempts failed or lost since the node was last reached
+	retry               actor.Timer
+	terminated          bool
+	stopped             bool
+	detector            *failureDetector
+	heartbeat           actor.Timer
 }
 
-func (state *endpointWriter) initialize() {
-	err := state.initializeInternal()
-	if err != nil {
-		plog.Error("EndpointWriter failed to connect", log.String("address", state.address), log.Error(err))
-		//Wait 2 seconds to restart and retry
-		//Replace with Exponential Backoff
-		time.Sleep(2 * time.Second)
-		panic(err)
-	}
+func (state *endpointWriter) initialize(ctx actor.Context) {
+	plog.Info("Started EndpointWriter", log.String("address", state.address))
+	state.detector = newFailureDetector(state.config, state.now(ctx))
+	state.scheduleHeartbeat(ctx)
+	state.connect(ctx)
 }
 
-func (state *endpointWriter) initializeInternal() error {
-	plog.Info("Started EndpointWriter", log.String("address", state.address))
+func (state *endpointWriter) now(ctx actor.Context) time.Time {
+	return ctx.Self().System().Clock().Now()
+}
+
+func (state *endpointWriter) connect(ctx actor.Context) {
 	plog.Info("EndpointWriter connecting", log.String("address", state.address))
-	conn, err := grpc.Dial(state.address, state.config.dialOptions...)
+	conn, err := state.config.transport.Dial(state.address)
 	if err != nil {
-		return err
+		state.connectFailed(ctx, err)
+		return
 	}
 	state.conn = conn
-	c := NewRemotingClient(conn)
-	resp, err := c.Connect(context.Background(), &ConnectRequest{})
-	if err != nil {
-		return err
+	state.closed = make(chan struct{})
+	state.defaultSerializerId = conn.DefaultSerializerID()
+	state.detector.reset(state.now(ctx))
+	go func(self *actor.PID, closed chan struct{}) {
+		select {
+		case <-conn.Lost():
+			select {
+			case <-closed:
+				//closed by the writer
+			default:
+				self.Tell(&endpointLost{conn: conn})
+			}
+		case <-closed:
+		}
+	}(ctx.Self(), state.closed)
+
+	plog.Info("EndpointWriter connected", log.String("address", state.address))
+	connected := &EndpointConnectedEvent{Address: state.address}
+	state.config.eventStream.Publish(connected)
+	if err := state.flush(); err != nil {
+		state.close()
+		state.connectFailed(ctx, err)
+		return
+	}
+	state.failures = 0
+}
+
+func (state *endpointWriter) connectFailed(ctx actor.Context, err error) {
+	state.failures++
+	plog.Error("EndpointWriter failed to connect", log.String("address", state.address), log.Int("failures", state.failures), log.Error(err))
+	if state.retry != nil {
+		state.retry.Stop()
 	}
-	state.defaultSerializerId = resp.DefaultSerializerId
+	if state.failures >= state.config.endpointWriterMaxRetries {
+		state.terminate()
+		return
+	}
+
+	self := ctx.Self()
+	state.retry = self.System().Clock().AfterFunc(state.config.reconnectBackoff(state.failures), func() {
+		self.Tell(&endpointReconnect{})
+	})
+}
 
-	//	log.Printf("Getting stream from address %v", state.address)
-	stream, err := c.Receive(context.Background(), state.config.callOptions...)
+// terminate notifies that the endpoint terminated, the endpoint manager then stops the writer
+func (state *endpointWriter) terminate() {
+	if state.terminated {
+		return
+	}
+	state.terminated = true
+	terminated := &EndpointTerminatedEvent{
+		Address: state.address,
+	}
+	state.config.eventStream.Publish(terminated)
+}
+
+func (state *endpointWriter) lost(ctx actor.Context, conn Connection) {
+	if conn != state.conn {
+		//a connection the writer already replaced
+		return
+	}
+	plog.Info("EndpointWriter lost connection to address", log.String("address", state.address))
+	state.close()
+	state.connectFailed(ctx, errConnectionLost)
+}
+
+func (state *endpointWriter) scheduleHeartbeat(ctx actor.Context) {
+	if state.config.heartbeatInterval <= 0 {
+		return
+	}
+	self := ctx.Self()
+	state.heartbeat = self.System().Clock().AfterFunc(state.config.heartbeatInterval, func() {
+		self.Tell(&endpointHeartbeat{})
+	})
+}
+
+// sendHeartbeat sends a heartbeat over the connection and updates the failure detector with the answered ones
+func (state *endpointWriter) sendHeartbeat(ctx actor.Context) {
+	defer state.scheduleHeartbeat(ctx)
+	if state.conn == nil {
+		//the reconnection handles the node while disconnected
+		return
+	}
+	answered, err := state.conn.Heartbeat()
 	if err != nil {
-		return err
+		plog.Debug("EndpointWriter failed to send heartbeat", log.String("address", state.address), log.Error(err))
+		state.close()
+		state.connectFailed(ctx, err)
+		return
 	}
-	go func() {
-		_, err := stream.Recv()
-		if err != nil {
-			plog.Info("EndpointWriter lost connection to address", log.String("address", state.address))
 
-			//notify that the endpoint terminated
-			terminated := &EndpointTerminatedEvent{
-				Address: state.address,
-			}
-			eventstream.Publish(terminated)
+	now := state.now(ctx)
+	if answered > 0 {
+		state.failures = 0
+	}
+	if answered > 0 && state.detector.heartbeat(now) {
+		plog.Info("EndpointWriter node reachable", log.String("address", state.address))
+		state.config.eventStream.Publish(&EndpointReachableEvent{Address: state.address})
+	}
+	if state.detector.check(now) {
+		switch state.detector.status {
+		case endpointSuspect:
+			plog.Info("EndpointWriter node suspect", log.String("address", state.address))
+			state.config.eventStream.Publish(&EndpointSuspectEvent{Address: state.address})
+		case endpointUnreachable:
+			plog.Info("EndpointWriter node unreachable", log.String("address", state.address))
+			state.config.eventStream.Publish(&EndpointUnreachableEvent{Address: state.address})
 		}
-	}()
+	}
+	if state.detector.expired(now) {
+		//the connection may be half open, replace it
+		state.close()
+		state.connectFailed(ctx, errUnreachable)
+	}
+}
 
-	plog.Info("EndpointWriter connected", log.String("address", state.address))
-	connected := &EndpointConnectedEvent{Address: state.address}
-	eventstream.Publish(connected)
-	state.stream = stream
+func (state *endpointWriter) close() {
+	//the connection is nil while disconnected
+	if state.conn != nil {
+		close(state.closed)
+		state.conn.Close()
+		state.conn = nil
+	}
+}
+
+func (state *endpointWriter) deliver(ctx actor.Context, deliveries []*remoteDeliver) {
+	if state.conn == nil {
+		state.bufferDeliveries(deliveries)
+		return
+	}
+	if err := state.send(deliveries); err != nil {
+		plog.Debug("EndpointWriter failed to send", log.String("address", state.address), log.Error(err))
+		state.bufferDeliveries(deliveries)
+		state.close()
+		state.connectFailed(ctx, err)
+		return
+	}
+	state.sent(len(deliveries))
+}
+
+func (state *endpointWriter) sent(n int) {
+	atomic.AddInt64(&state.counters.queued, -int64(n))
+	state.failures = 0
+}
+
+// bufferDeliveries keeps the deliveries until the writer reconnects, those over the buffer size are dead letters
+func (state *endpointWriter) bufferDeliveries(deliveries []*remoteDeliver) {
+	free := state.config.endpointWriterBufferSize - len(state.buffer)
+	if free < 0 {
+		free = 0
+	}
+	if len(deliveries) > free {
+		state.deadLetters(deliveries[free:])
+		deliveries = deliveries[:free]
+	}
+	state.buffer = append(state.buffer, deliveries...)
+}
+
+// flush sends the buffered deliveries, it stops at the first failure and keeps the deliveries not sent
+func (state *endpointWriter) flush() error {
+	batchSize := state.config.endpointWriterBatchSize
+	for len(state.buffer) > 0 {
+		n := batchSize
+		if n <= 0 || n > len(state.buffer) {
+			n = len(state.buffer)
+		}
+		if err := state.send(state.buffer[:n]); err != nil {
+			return err
+		}
+		state.buffer = state.buffer[n:]
+		state.sent(n)
+	}
+	state.buffer = nil
 	return nil
 }
 
-func (state *endpointWriter) sendEnvelopes(msg []interface{}, ctx actor.Context) {
-	envelopes := make([]*MessageEnvelope, len(msg))
+func (state *endpointWriter) deadLetters(deliveries []*remoteDeliver) {
+	atomic.AddInt64(&state.counters.queued, -int64(len(deliveries)))
+	atomic.AddInt64(&state.counters.dropped, int64(len(deliveries)))
+	for _, rd := range deliveries {
+		state.config.eventStream.Publish(&actor.DeadLetterEvent{
+			PID:     rd.target,
+			Message: rd.message,
+			Sender:  rd.sender,
+		})
+	}
+}
+
+func (state *endpointWriter) send(deliveries []*remoteDeliver) error {
+	envelopes := make([]*MessageEnvelope, len(deliveries))
 
 	//type name uniqueness map name string to type index
 	typeNames := make(map[string]int32)
@@ -121,9 +304,7 @@ func (state *endpointWriter) sendEnvelopes(msg []interface{}, ctx actor.Context)
 	var typeID int32
 	var targetID int32
 	var serializerID int32
-	for i, tmp := range msg {
-		rd := tmp.(*remoteDeliver)
-
+	for i, rd := range deliveries {
 		if rd.serializerID == -1 {
 			serializerID = state.defaultSerializerId
 		} else {
@@ -158,13 +339,7 @@ func (state *endpointWriter) sendEnvelopes(msg []interface{}, ctx actor.Context)
 		TargetNames: targetNamesArr,
 		Envelopes:   envelopes,
 	}
-	err := state.stream.Send(batch)
-
-	if err != nil {
-		ctx.Stash()
-		plog.Debug("gRPC Failed to send", log.String("address", state.address))
-		panic("restart it")
-	}
+	return state.conn.SendBatch(batch)
 }
 
 func addToLookup(m map[string]int32, name string, a []string) (int32, []string) {
@@ -181,16 +356,70 @@ func addToLookup(m map[string]int32, name string, a []string) (int32, []string)
 func (state *endpointWriter) Receive(ctx actor.Context) {
 	switch msg := ctx.Message().(type) {
 	case *actor.Started:
-		state.initialize()
-	case *actor.Stopped:
-		state.conn.Close()
-	case *actor.Restarting:
-		state.conn.Close()
+		state.initialize(ctx)
+	case *actor.Stopped, *actor.Restarting:
+		state.stop()
 	case []interface{}:
-		state.sendEnvelopes(msg, ctx)
+		//the mailbox batches all the user messages, including those of the writer itself
+		deliveries := make([]*remoteDeliver, 0, len(msg))
+		for _, m := range msg {
+			if rd, ok := m.(*remoteDeliver); ok {
+				deliveries = append(deliveries, rd)
+				continue
+			}
+			if state.stopped {
+				//the messages left in the mailbox of a stopped writer
+				continue
+			}
+			switch m := m.(type) {
+			case *endpointLost:
+				state.lost(ctx, m.conn)
+			case *endpointHeartbeat:
+				state.sendHeartbeat(ctx)
+			case *endpointReconnect:
+				if state.conn == nil && !state.terminated {
+					state.connect(ctx)
+				}
+			}
+		}
+		if state.stopped {
+			//the deliveries are never sent once the writer stopped
+			state.deadLetters(deliveries)
+		} else if len(deliveries) > 0 {
+			state.deliver(ctx, deliveries)
+		}
 	case actor.SystemMessage, actor.AutoReceiveMessage:
 		//ignore
 	default:
 		plog.Error("EndpointWriter received unknown message", log.String("address", state.address), log.TypeOf("type", msg), log.Message(msg))
 	}
 }
+
+func (state *endpointWriter) stop() {
+	state.stopped = true
+	if state.retry != nil {
+		state.retry.Stop()
+	}
+	if state.heartbeat != nil {
+		state.heartbeat.Stop()
+	}
+	state.close()
+	state.deadLetters(state.buffer)
+	state.buffer = nil
+}
+
+// reconnectBackoff returns the delay before the next connection attempt after failures failed ones,
+// it doubles from the initial backoff up to the maximum and half of it is random jitter
+func (config *remoteConfig) reconnectBackoff(failures int) time.Duration {
+	backoff := config.endpointWriterInitialBackoff
+	if backoff <= 0 {
+		return 0
+	}
+	for i := 1; i < failures && backoff < config.endpointWriterMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > config.endpointWriterMaxBackoff {
+		backoff = config.endpointWriterMaxBackoff
+	}
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
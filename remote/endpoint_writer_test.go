@@ -0,0 +1,296 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+	"github.com/OnyxPay/OnyxChain-eventbus/actor/actortest"
+	"github.com/stretchr/testify/assert"
+)
+
+var errTestDial = errors.New("test: dial failed")
+
+// testTransport is a Transport whose connection attempts fail until the test lets them connect
+type testTransport struct {
+	mu      sync.Mutex
+	connect bool
+	dials   chan *testConnection // the connection of each attempt, nil when it failed
+}
+
+func newTestTransport() *testTransport {
+	return &testTransport{dials: make(chan *testConnection, 100)}
+}
+
+func (t *testTransport) setConnect(connect bool) {
+	t.mu.Lock()
+	t.connect = connect
+	t.mu.Unlock()
+}
+
+func (t *testTransport) Listen(address string, receiver BatchReceiver) (string, error) {
+	return address, nil
+}
+
+func (t *testTransport) Dial(address string) (Connection, error) {
+	t.mu.Lock()
+	connect := t.connect
+	t.mu.Unlock()
+	if !connect {
+		t.dials <- nil
+		return nil, errTestDial
+	}
+	c := &testConnection{lost: make(chan struct{})}
+	t.dials <- c
+	return c, nil
+}
+
+func (t *testTransport) Stop(graceful bool) {}
+
+type testConnection struct {
+	answered int32 // the heartbeats answered on each call of Heartbeat
+	lostOnce sync.Once
+	lost     chan struct{}
+}
+
+func (c *testConnection) SendBatch(batch *MessageBatch) error {
+	return nil
+}
+
+func (c *testConnection) Heartbeat() (int, error) {
+	return int(atomic.LoadInt32(&c.answered)), nil
+}
+
+func (c *testConnection) DefaultSerializerID() int32 {
+	return DefaultSerializerID
+}
+
+func (c *testConnection) Lost() <-chan struct{} {
+	return c.lost
+}
+
+func (c *testConnection) Close() error {
+	c.lostOnce.Do(func() {
+		close(c.lost)
+	})
+	return nil
+}
+
+// expectDial returns the connection of the next connection attempt, nil if it failed
+func (t *testTransport) expectDial(tb testing.TB) *testConnection {
+	tb.Helper()
+	select {
+	case c := <-t.dials:
+		return c
+	case <-time.After(time.Second):
+		tb.Fatal("expected a connection attempt")
+		return nil
+	}
+}
+
+func (t *testTransport) expectNoDial(tb testing.TB) {
+	tb.Helper()
+	select {
+	case <-t.dials:
+		tb.Fatal("unexpected connection attempt")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// waitTimer waits until the endpoint writer scheduled its next connection attempt or heartbeat
+func waitTimer(tb testing.TB, clock *actortest.VirtualClock) {
+	tb.Helper()
+	deadline := time.Now().Add(time.Second)
+	for clock.Pending() == 0 {
+		if time.Now().After(deadline) {
+			tb.Fatal("expected a timer to be scheduled")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// testNodes numbers the addresses of the test remotes, a PID resolves to the system with its address
+var testNodes uint32
+
+// startTestRemote starts a remote over transport on a system with a virtual clock and without heartbeats
+func startTestRemote(t *testing.T, transport Transport, options ...RemotingOption) (*Remote, *actortest.VirtualClock, func()) {
+	clock := actortest.NewVirtualClock(time.Unix(0, 0))
+	system := actor.NewSystem(actor.Config{Clock: clock})
+	options = append([]RemotingOption{WithTransport(transport), WithHeartbeat(0, 0, 0)}, options...)
+	address := fmt.Sprintf("node1-%d:8080", atomic.AddUint32(&testNodes, 1))
+	r, err := StartSystem(system, address, options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r, clock, func() {
+		r.Shutdown(false)
+		system.Shutdown()
+	}
+}
+
+// subscribeEvents returns the events of type sample published on the event stream of system
+func subscribeEvents(system *actor.ActorSystem, sample interface{}) (chan interface{}, func()) {
+	events := make(chan interface{}, 100)
+	sub := system.EventStream().SubscribeType(sample, func(evt interface{}) {
+		events <- evt
+	})
+	return events, func() {
+		system.EventStream().Unsubscribe(sub)
+	}
+}
+
+func expectEvent(tb testing.TB, events chan interface{}) interface{} {
+	tb.Helper()
+	select {
+	case evt := <-events:
+		return evt
+	case <-time.After(time.Second):
+		tb.Fatal("expected an event")
+		return nil
+	}
+}
+
+func TestEndpointWriter_Backoff(t *testing.T) {
+	transport := newTestTransport()
+	r, clock, stop := startTestRemote(t, transport,
+		WithEndpointWriterBackoff(time.Second, 4*time.Second), WithEndpointWriterMaxRetries(10))
+	defer stop()
+	system := r.System()
+
+	system.NewPID("node2:8080", "target").Tell(&ActorPidRequest{Name: "0"})
+	assert.Nil(t, transport.expectDial(t))
+
+	//half of the backoff is jitter, the next attempt is in its second half
+	for _, backoff := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second} {
+		waitTimer(t, clock)
+		clock.Advance(backoff/2 - time.Millisecond)
+		transport.expectNoDial(t)
+		clock.Advance(backoff/2 + time.Millisecond)
+		assert.Nil(t, transport.expectDial(t))
+	}
+}
+
+func TestEndpointWriter_BufferOverflow(t *testing.T) {
+	transport := newTestTransport()
+	r, _, stop := startTestRemote(t, transport, WithEndpointWriterBufferSize(2))
+	defer stop()
+	system := r.System()
+	deadLetters, unsubscribe := subscribeEvents(system, (*actor.DeadLetterEvent)(nil))
+	defer unsubscribe()
+
+	target := system.NewPID("node2:8080", "target")
+	for _, name := range []string{"0", "1", "2", "3"} {
+		target.Tell(&ActorPidRequest{Name: name})
+	}
+	assert.Nil(t, transport.expectDial(t))
+
+	//the buffer keeps the first messages while the writer reconnects
+	for _, name := range []string{"2", "3"} {
+		deadLetter := expectEvent(t, deadLetters).(*actor.DeadLetterEvent)
+		assert.Equal(t, &ActorPidRequest{Name: name}, deadLetter.Message)
+		assert.Equal(t, "target", deadLetter.PID.Id)
+	}
+	select {
+	case evt := <-deadLetters:
+		t.Fatalf("unexpected dead letter %v", evt)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestEndpointWriter_MaxRetries(t *testing.T) {
+	transport := newTestTransport()
+	r, clock, stop := startTestRemote(t, transport,
+		WithEndpointWriterBackoff(time.Second, time.Second), WithEndpointWriterMaxRetries(3))
+	defer stop()
+	system := r.System()
+	terminated, unsubscribeTerminated := subscribeEvents(system, (*EndpointTerminatedEvent)(nil))
+	defer unsubscribeTerminated()
+	deadLetters, unsubscribeDeadLetters := subscribeEvents(system, (*actor.DeadLetterEvent)(nil))
+	defer unsubscribeDeadLetters()
+
+	system.NewPID("node2:8080", "target").Tell(&ActorPidRequest{Name: "0"})
+	assert.Nil(t, transport.expectDial(t))
+	for i := 0; i < 2; i++ {
+		waitTimer(t, clock)
+		assert.Empty(t, terminated)
+		clock.Advance(time.Second)
+		assert.Nil(t, transport.expectDial(t))
+	}
+
+	assert.Equal(t, &EndpointTerminatedEvent{Address: "node2:8080"}, expectEvent(t, terminated))
+	//the buffered messages are dead letters once the writer stopped
+	assert.Equal(t, &ActorPidRequest{Name: "0"}, expectEvent(t, deadLetters).(*actor.DeadLetterEvent).Message)
+	clock.Advance(time.Minute)
+	transport.expectNoDial(t)
+}
+
+func TestEndpointWriter_ConnectResetsFailures(t *testing.T) {
+	transport := newTestTransport()
+	//without a buffer no message is sent on connecting, the connection alone resets the failures
+	r, clock, stop := startTestRemote(t, transport,
+		WithEndpointWriterBackoff(time.Second, time.Second), WithEndpointWriterMaxRetries(3), WithEndpointWriterBufferSize(0))
+	defer stop()
+	system := r.System()
+	terminated, unsubscribe := subscribeEvents(system, (*EndpointTerminatedEvent)(nil))
+	defer unsubscribe()
+
+	system.NewPID("node2:8080", "target").Tell(&ActorPidRequest{Name: "0"})
+	assert.Nil(t, transport.expectDial(t))
+	waitTimer(t, clock)
+	clock.Advance(time.Second)
+	assert.Nil(t, transport.expectDial(t))
+
+	transport.setConnect(true)
+	waitTimer(t, clock)
+	clock.Advance(time.Second)
+	conn := transport.expectDial(t)
+	if conn == nil {
+		t.Fatal("expected the writer to connect")
+	}
+
+	//losing the connection is the first failure since the node was reached
+	transport.setConnect(false)
+	conn.Close()
+	for i := 0; i < 2; i++ {
+		waitTimer(t, clock)
+		assert.Empty(t, terminated)
+		clock.Advance(time.Second)
+		assert.Nil(t, transport.expectDial(t))
+	}
+	assert.Equal(t, &EndpointTerminatedEvent{Address: "node2:8080"}, expectEvent(t, terminated))
+}
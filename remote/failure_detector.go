@@ -0,0 +1,96 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package remote
+
+import "time"
+
+type endpointStatus int
+
+const (
+	endpointReachable endpointStatus = iota
+	endpointSuspect
+	endpointUnreachable
+)
+
+// failureDetector tracks the heartbeats answered by a node, it suspects the node once it answered none
+// for the suspect timeout and considers it unreachable after the unreachable timeout
+type failureDetector struct {
+	suspectTimeout     time.Duration
+	unreachableTimeout time.Duration
+	last               time.Time
+	status             endpointStatus
+}
+
+func newFailureDetector(config *remoteConfig, now time.Time) *failureDetector {
+	return &failureDetector{
+		suspectTimeout:     config.suspectTimeout,
+		unreachableTimeout: config.unreachableTimeout,
+		last:               now,
+	}
+}
+
+// reset restarts the timeouts, such as when a new connection is established
+func (d *failureDetector) reset(now time.Time) {
+	d.last = now
+}
+
+// heartbeat records answered heartbeats, it returns true if the node was suspect or unreachable
+func (d *failureDetector) heartbeat(now time.Time) bool {
+	d.last = now
+	if d.status == endpointReachable {
+		return false
+	}
+	d.status = endpointReachable
+	return true
+}
+
+// check updates the status from the time elapsed since the last answered heartbeat, it returns true if it changed
+func (d *failureDetector) check(now time.Time) bool {
+	status := d.status
+	elapsed := now.Sub(d.last)
+	switch {
+	case elapsed >= d.unreachableTimeout:
+		status = endpointUnreachable
+	case elapsed >= d.suspectTimeout && status == endpointReachable:
+		status = endpointSuspect
+	}
+	if status == d.status {
+		return false
+	}
+	d.status = status
+	return true
+}
+
+// expired reports whether the node answered no heartbeat for the unreachable timeout
+func (d *failureDetector) expired(now time.Time) bool {
+	return now.Sub(d.last) >= d.unreachableTimeout
+}
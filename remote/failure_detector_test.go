@@ -0,0 +1,125 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package remote
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor/actortest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailureDetector(t *testing.T) {
+	clock := actortest.NewVirtualClock(time.Unix(0, 0))
+	config := &remoteConfig{suspectTimeout: 3 * time.Second, unreachableTimeout: 10 * time.Second}
+	d := newFailureDetector(config, clock.Now())
+
+	clock.Advance(3*time.Second - time.Millisecond)
+	assert.False(t, d.check(clock.Now()))
+	clock.Advance(time.Millisecond)
+	assert.True(t, d.check(clock.Now()))
+	assert.Equal(t, endpointSuspect, d.status)
+	assert.False(t, d.check(clock.Now()))
+
+	assert.True(t, d.heartbeat(clock.Now()))
+	assert.Equal(t, endpointReachable, d.status)
+	assert.False(t, d.heartbeat(clock.Now()))
+
+	//a node answering nothing becomes unreachable, even if it was not checked while suspect
+	clock.Advance(10 * time.Second)
+	assert.True(t, d.expired(clock.Now()))
+	assert.True(t, d.check(clock.Now()))
+	assert.Equal(t, endpointUnreachable, d.status)
+
+	d.reset(clock.Now())
+	assert.False(t, d.expired(clock.Now()))
+	//an unreachable node is reachable again once it answers
+	assert.False(t, d.check(clock.Now()))
+	assert.True(t, d.heartbeat(clock.Now()))
+}
+
+func TestEndpointWriter_Heartbeat(t *testing.T) {
+	transport := newTestTransport()
+	transport.setConnect(true)
+	r, clock, stop := startTestRemote(t, transport,
+		WithHeartbeat(time.Second, 3*time.Second, 5*time.Second), WithEndpointWriterBackoff(time.Second, time.Second))
+	defer stop()
+	events := make(chan interface{}, 100)
+	sub := r.System().EventStream().Subscribe(func(evt interface{}) {
+		switch evt.(type) {
+		case *EndpointSuspectEvent, *EndpointUnreachableEvent, *EndpointReachableEvent:
+			events <- evt
+		}
+	})
+	defer r.System().EventStream().Unsubscribe(sub)
+	tick := func() {
+		t.Helper()
+		waitTimer(t, clock)
+		clock.Advance(time.Second)
+	}
+
+	r.System().NewPID("node2:8080", "target").Tell(&ActorPidRequest{Name: "0"})
+	conn := transport.expectDial(t)
+	if conn == nil {
+		t.Fatal("expected the writer to connect")
+	}
+
+	for i := 0; i < 3; i++ {
+		assert.Empty(t, events)
+		tick()
+	}
+	assert.Equal(t, &EndpointSuspectEvent{Address: "node2:8080"}, expectEvent(t, events))
+
+	atomic.StoreInt32(&conn.answered, 1)
+	tick()
+	assert.Equal(t, &EndpointReachableEvent{Address: "node2:8080"}, expectEvent(t, events))
+
+	//a connection answering no heartbeat for the unreachable timeout is replaced
+	atomic.StoreInt32(&conn.answered, 0)
+	for i := 0; i < 5; i++ {
+		tick()
+	}
+	assert.Equal(t, &EndpointSuspectEvent{Address: "node2:8080"}, expectEvent(t, events))
+	assert.Equal(t, &EndpointUnreachableEvent{Address: "node2:8080"}, expectEvent(t, events))
+	<-conn.Lost()
+	tick()
+	assert.NotNil(t, transport.expectDial(t))
+}
+
+func TestEndpointWriter_HeartbeatEnabledByDefault(t *testing.T) {
+	config := defaultRemoteConfig()
+	assert.Equal(t, time.Second, config.heartbeatInterval)
+	assert.True(t, config.suspectTimeout > config.heartbeatInterval)
+	assert.True(t, config.unreachableTimeout > config.suspectTimeout)
+}
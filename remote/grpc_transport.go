@@ -0,0 +1,247 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package remote
+
+import (
+	"errors"
+	"io/ioutil"
+	slog "log"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/log"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/grpclog"
+	grpcpeer "google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var grpcLoggerOnce sync.Once
+
+// grpcTransport is the default Transport, it streams the batches over gRPC
+type grpcTransport struct {
+	config        *remoteConfig
+	dialOptions   []grpc.DialOption
+	serverOptions []grpc.ServerOption
+	server        *grpc.Server
+	receiver      BatchReceiver
+}
+
+// newGRPCTransport returns the gRPC transport of config, in cleartext unless config has TLS files
+func newGRPCTransport(config *remoteConfig) (*grpcTransport, error) {
+	t := &grpcTransport{config: config}
+	if config.certFile == "" && (config.keyFile != "" || config.caFile != "") {
+		return nil, errors.New("remote: TLS needs a certificate file")
+	}
+	if config.certFile != "" && (config.keyFile == "" || config.caFile == "") {
+		return nil, errors.New("remote: TLS needs a key file and a CA file with the certificate file")
+	}
+	if config.certFile == "" {
+		t.dialOptions = append([]grpc.DialOption{grpc.WithInsecure()}, config.dialOptions...)
+		t.serverOptions = config.serverOptions
+		return t, nil
+	}
+
+	server, client, err := loadTLS(config.certFile, config.keyFile, config.caFile)
+	if err != nil {
+		return nil, err
+	}
+	t.dialOptions = append([]grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(client))}, config.dialOptions...)
+	t.serverOptions = append([]grpc.ServerOption{grpc.Creds(credentials.NewTLS(server))}, config.serverOptions...)
+	return t, nil
+}
+
+func (t *grpcTransport) Listen(address string, receiver BatchReceiver) (string, error) {
+	//the gRPC logger is global, replacing it while another server logs is a race
+	grpcLoggerOnce.Do(func() {
+		grpclog.SetLogger(slog.New(ioutil.Discard, "", 0))
+	})
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		return "", err
+	}
+	t.receiver = receiver
+	t.server = grpc.NewServer(t.serverOptions...)
+	RegisterRemotingServer(t.server, t)
+	go t.server.Serve(lis)
+	return lis.Addr().String(), nil
+}
+
+func (t *grpcTransport) Connect(ctx context.Context, req *ConnectRequest) (*ConnectResponse, error) {
+	if !t.receiver.Accepting() {
+		return nil, status.Error(codes.Canceled, "Suspended")
+	}
+
+	return &ConnectResponse{DefaultSerializerId: DefaultSerializerID}, nil
+}
+
+func (t *grpcTransport) Receive(stream Remoting_ReceiveServer) error {
+	peer := streamPeer(stream.Context())
+	for {
+		batch, err := stream.Recv()
+		if err != nil {
+			plog.Debug("EndpointReader failed to read", log.Error(err))
+			return err
+		}
+		if len(batch.Envelopes) == 0 {
+			//a heartbeat, answered while the batches are accepted
+			if t.receiver.Accepting() {
+				if err := stream.Send(&Unit{}); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := t.receiver.ReceiveBatch(peer, batch); err != nil {
+			return err
+		}
+	}
+}
+
+// streamPeer returns the peer of a stream, identified by the verified certificate of its TLS connection
+func streamPeer(ctx context.Context) *Peer {
+	p, ok := grpcpeer.FromContext(ctx)
+	if !ok {
+		return &Peer{}
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return &Peer{}
+	}
+	cert := info.State.VerifiedChains[0][0]
+	return &Peer{Identity: cert.Subject.CommonName, Certificate: cert}
+}
+
+func (t *grpcTransport) Dial(address string) (Connection, error) {
+	conn, err := grpc.Dial(address, t.dialOptions...)
+	if err != nil {
+		return nil, err
+	}
+	c := NewRemotingClient(conn)
+	resp, err := c.Connect(context.Background(), &ConnectRequest{})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	stream, err := c.Receive(context.Background(), t.config.callOptions...)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	gc := &grpcConnection{
+		conn:                conn,
+		stream:              stream,
+		defaultSerializerID: resp.DefaultSerializerId,
+		lost:                make(chan struct{}),
+	}
+	go func() {
+		//the server answers the heartbeats on the stream until the connection ends
+		for {
+			if _, err := stream.Recv(); err != nil {
+				gc.setLost()
+				return
+			}
+			atomic.AddInt32(&gc.answered, 1)
+		}
+	}()
+	return gc, nil
+}
+
+func (t *grpcTransport) Stop(graceful bool) {
+	s := t.server
+	if !graceful {
+		s.Stop()
+		plog.Info("Killed Proto.Actor server")
+		return
+	}
+
+	//For some reason GRPC doesn't want to stop
+	//Setup timeout as walkaround but need to figure out in the future.
+	//TODO: grpc not stopping
+	c := make(chan bool, 1)
+	go func() {
+		s.GracefulStop()
+		c <- true
+	}()
+
+	select {
+	case <-c:
+		plog.Info("Stopped Proto.Actor server")
+	case <-time.After(time.Second * 10):
+		s.Stop()
+		plog.Info("Stopped Proto.Actor server timeout")
+	}
+}
+
+type grpcConnection struct {
+	conn                *grpc.ClientConn
+	stream              Remoting_ReceiveClient
+	defaultSerializerID int32
+	answered            int32
+	lostOnce            sync.Once
+	lost                chan struct{}
+}
+
+func (c *grpcConnection) SendBatch(batch *MessageBatch) error {
+	return c.stream.Send(batch)
+}
+
+func (c *grpcConnection) Heartbeat() (int, error) {
+	answered := atomic.SwapInt32(&c.answered, 0)
+	return int(answered), c.stream.Send(&MessageBatch{})
+}
+
+func (c *grpcConnection) DefaultSerializerID() int32 {
+	return c.defaultSerializerID
+}
+
+func (c *grpcConnection) Lost() <-chan struct{} {
+	return c.lost
+}
+
+func (c *grpcConnection) Close() error {
+	err := c.conn.Close()
+	c.setLost()
+	return err
+}
+
+func (c *grpcConnection) setLost() {
+	c.lostOnce.Do(func() {
+		close(c.lost)
+	})
+}
@@ -41,6 +41,22 @@ type EndpointConnectedEvent struct {
 	Address string
 }
 
+// EndpointSuspectEvent is published when a node did not answer the heartbeats for the suspect timeout
+type EndpointSuspectEvent struct {
+	Address string
+}
+
+// EndpointUnreachableEvent is published when a node did not answer the heartbeats for the unreachable timeout,
+// the endpoint writer then reconnects to it
+type EndpointUnreachableEvent struct {
+	Address string
+}
+
+// EndpointReachableEvent is published when a suspect or unreachable node answers the heartbeats again
+type EndpointReachableEvent struct {
+	Address string
+}
+
 type remoteWatch struct {
 	Watcher *actor.PID
 	Watchee *actor.PID
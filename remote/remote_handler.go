@@ -34,7 +34,7 @@ package remote
 
 import "github.com/OnyxPay/OnyxChain-eventbus/actor"
 
-func remoteHandler(pid *actor.PID) (actor.Process, bool) {
-	ref := newProcess(pid)
+func (r *Remote) remoteHandler(pid *actor.PID) (actor.Process, bool) {
+	ref := newProcess(pid, r)
 	return ref, true
 }
@@ -32,25 +32,44 @@ limitations under the License.
 package remote
 
 import (
+	"sync/atomic"
+
 	"github.com/OnyxPay/OnyxChain-eventbus/actor"
 )
 
 type process struct {
-	pid *actor.PID
+	pid    *actor.PID
+	remote *Remote
 }
 
-func newProcess(pid *actor.PID) actor.Process {
+func newProcess(pid *actor.PID, remote *Remote) actor.Process {
 	return &process{
-		pid: pid,
+		pid:    pid,
+		remote: remote,
 	}
 }
 
 func (ref *process) SendUserMessage(pid *actor.PID, message interface{}) {
 	header, msg, sender := actor.UnwrapEnvelope(message)
-	SendMessage(pid, header, msg, sender, -1)
+	ref.remote.SendMessage(pid, header, msg, sender, -1)
 }
 
+// SendMessage sends a message to a remote PID through the remoting of the default actor system
 func SendMessage(pid *actor.PID, header actor.ReadonlyMessageHeader, message interface{}, sender *actor.PID, serializerID int32) {
+	defaultRemote.SendMessage(pid, header, message, sender, serializerID)
+}
+
+// SendMessage sends a message to a remote PID, once the remote is shutting down it is published as a dead letter
+func (r *Remote) SendMessage(pid *actor.PID, header actor.ReadonlyMessageHeader, message interface{}, sender *actor.PID, serializerID int32) {
+	if atomic.LoadInt32(&r.stopping) == 1 {
+		r.config.eventStream.Publish(&actor.DeadLetterEvent{
+			PID:     pid,
+			Message: message,
+			Sender:  sender,
+		})
+		return
+	}
+
 	rd := &remoteDeliver{
 		header:       header,
 		message:      message,
@@ -59,7 +78,7 @@ func SendMessage(pid *actor.PID, header actor.ReadonlyMessageHeader, message int
 		serializerID: serializerID,
 	}
 
-	endpointManager.remoteDeliver(rd)
+	r.endpointManager.remoteDeliver(rd)
 }
 
 func (ref *process) SendSystemMessage(pid *actor.PID, message interface{}) {
@@ -71,15 +90,15 @@ func (ref *process) SendSystemMessage(pid *actor.PID, message interface{}) {
 			Watcher: msg.Watcher,
 			Watchee: pid,
 		}
-		endpointManager.remoteWatch(rw)
+		ref.remote.endpointManager.remoteWatch(rw)
 	case *actor.Unwatch:
 		ruw := &remoteUnwatch{
 			Watcher: msg.Watcher,
 			Watchee: pid,
 		}
-		endpointManager.remoteUnwatch(ruw)
+		ref.remote.endpointManager.remoteUnwatch(ruw)
 	default:
-		SendMessage(pid, nil, message, nil, -1)
+		ref.remote.SendMessage(pid, nil, message, nil, -1)
 	}
 }
 
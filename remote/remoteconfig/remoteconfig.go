@@ -0,0 +1,50 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+// Package remoteconfig configures the remoting from the settings of a node configuration, such as the IsTLS,
+// CertPath, KeyPath and CAPath settings of common/config
+package remoteconfig
+
+import (
+	"github.com/OnyxPay/OnyxChain-eventbus/remote"
+)
+
+// WithTLS secures the gRPC transport with mutual TLS when isTLS is set, with the certificate, key and CA files
+// as in remote.WithTLS. It leaves the connections in cleartext otherwise, e.g.
+//
+//	remoteconfig.WithTLS(config.DefConfig.IsTLS, config.DefConfig.CertPath, config.DefConfig.KeyPath, config.DefConfig.CAPath)
+func WithTLS(isTLS bool, certPath, keyPath, caPath string) remote.RemotingOption {
+	if !isTLS {
+		//without files the connections are in cleartext
+		return remote.WithTLS("", "", "")
+	}
+	return remote.WithTLS(certPath, keyPath, caPath)
+}
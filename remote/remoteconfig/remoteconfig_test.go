@@ -0,0 +1,58 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package remoteconfig
+
+import (
+	"testing"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+	"github.com/OnyxPay/OnyxChain-eventbus/remote"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTLS(t *testing.T) {
+	system := actor.NewSystem(actor.Config{})
+	defer system.Shutdown()
+
+	//the files are ignored without TLS
+	r, err := remote.StartSystem(system, "127.0.0.1:0", WithTLS(false, "missing.pem", "missing.key", "missing-ca.pem"))
+	if assert.NoError(t, err) {
+		r.Shutdown(false)
+	}
+
+	other := actor.NewSystem(actor.Config{})
+	defer other.Shutdown()
+	_, err = remote.StartSystem(other, "127.0.0.1:0", WithTLS(true, "missing.pem", "missing.key", "missing-ca.pem"))
+	assert.Error(t, err)
+	_, err = remote.StartSystem(other, "127.0.0.1:0", WithTLS(true, "missing.pem", "", ""))
+	assert.Error(t, err)
+}
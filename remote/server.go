@@ -32,75 +32,152 @@ limitations under the License.
 package remote
 
 import (
-	"io/ioutil"
-	slog "log"
-	"net"
-	"os"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+	"github.com/OnyxPay/OnyxChain-eventbus/eventhub"
 	"github.com/OnyxPay/OnyxChain-eventbus/log"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/grpclog"
 )
 
-var (
-	s         *grpc.Server
-	edpReader *endpointReader
-)
+// Remote is the remoting of an actor system, its transport and its endpoints to the other nodes
+type Remote struct {
+	system          *actor.ActorSystem
+	config          *remoteConfig
+	edpReader       *endpointReader
+	endpointManager *endpointManagerValue
+	activatorPid    *actor.PID
+	stopping        int32
+	// eventHubs exchange subscriptions with the connected nodes
+	eventHubs []*eventhub.EventHub
+}
+
+var defaultRemote *Remote
 
-// Start the remote server
-func Start(address string, options ...RemotingOption) {
-	grpclog.SetLogger(slog.New(ioutil.Discard, "", 0))
-	lis, err := net.Listen("tcp", address)
+// Start the remote server of the default actor system, it returns an error if the configuration is invalid
+// or the address cannot be listened on
+func Start(address string, options ...RemotingOption) error {
+	r, err := StartSystem(actor.DefaultSystem(), address, options...)
 	if err != nil {
-		plog.Error("failed to listen", log.Error(err))
-		os.Exit(1)
+		return err
 	}
+	defaultRemote = r
+	return nil
+}
+
+// DefaultRemote returns the remote of the default actor system, or nil if it is not started
+func DefaultRemote() *Remote {
+	return defaultRemote
+}
+
+// StartSystem starts the remote server of an actor system, the PIDs of the system then get the server address.
+// It returns an error if the configuration is invalid, such as TLS files that cannot be loaded, or the address cannot be listened on
+func StartSystem(system *actor.ActorSystem, address string, options ...RemotingOption) (*Remote, error) {
 	config := defaultRemoteConfig()
 
 	for _, option := range options {
 		option(config)
 	}
+	if config.eventStream == nil {
+		config.eventStream = system.EventStream()
+	}
+	if config.transport == nil {
+		transport, err := newGRPCTransport(config)
+		if err != nil {
+			plog.Error("failed to load TLS", log.Error(err))
+			return nil, err
+		}
+		config.transport = transport
+	}
 
-	address = lis.Addr().String()
-	actor.ProcessRegistry.RegisterAddressResolver(remoteHandler)
-	actor.ProcessRegistry.Address = address
+	r := &Remote{
+		system:    system,
+		config:    config,
+		eventHubs: config.eventHubs,
+	}
+	if !config.eventHubsSet && system == actor.DefaultSystem() {
+		r.eventHubs = []*eventhub.EventHub{eventhub.GlobalEventHub}
+	}
 
-	spawnActivatorActor()
-	startEndpointManager(config)
+	//batches are refused until the endpoint manager is started
+	r.edpReader = &endpointReader{remote: r, suspended: 1}
+	address, err := config.transport.Listen(address, r.edpReader)
+	if err != nil {
+		plog.Error("failed to listen", log.Error(err))
+		return nil, err
+	}
+	system.ProcessRegistry.RegisterAddressResolver(r.remoteHandler)
+	system.SetAddress(address)
+
+	r.spawnActivatorActor()
+	r.startEndpointManager()
+	r.edpReader.suspend(false)
 
-	s = grpc.NewServer(config.serverOptions...)
-	edpReader = &endpointReader{}
-	RegisterRemotingServer(s, edpReader)
 	plog.Info("Starting Proto.Actor server", log.String("address", address))
-	go s.Serve(lis)
+	return r, nil
+}
+
+// System returns the actor system of the remote
+func (r *Remote) System() *actor.ActorSystem {
+	return r.system
 }
 
+// Shutdown the remote server of the default actor system
 func Shutdown(graceful bool) {
+	defaultRemote.Shutdown(graceful)
+}
+
+// ShutdownTimeout stops the remote server, the messages then sent to remote PIDs are dead letters.
+// A graceful shutdown waits up to timeout for the endpoint writers to send their queued messages before closing the endpoints.
+// It returns an UndeliveredError if messages were left undelivered
+func (r *Remote) ShutdownTimeout(graceful bool, timeout time.Duration) error {
+	atomic.StoreInt32(&r.stopping, 1)
+	r.edpReader.suspend(true)
+	dropped := r.endpointManager.dropped()
 	if graceful {
-		edpReader.suspend(true)
-		stopEndpointManager()
-		stopActivatorActor()
-
-		//For some reason GRPC doesn't want to stop
-		//Setup timeout as walkaround but need to figure out in the future.
-		//TODO: grpc not stopping
-		c := make(chan bool, 1)
-		go func() {
-			s.GracefulStop()
-			c <- true
-		}()
-
-		select {
-		case <-c:
-			plog.Info("Stopped Proto.Actor server")
-		case <-time.After(time.Second * 10):
-			s.Stop()
-			plog.Info("Stopped Proto.Actor server timeout")
-		}
-	} else {
-		s.Stop()
-		plog.Info("Killed Proto.Actor server")
+		r.endpointManager.drain(r.system.Clock(), timeout)
+	}
+	undelivered := r.endpointManager.undelivered(dropped)
+	if graceful {
+		r.stopEndpointManager()
+		r.stopActivatorActor()
+	}
+	r.config.transport.Stop(graceful)
+
+	if len(undelivered) > 0 {
+		return &UndeliveredError{Undelivered: undelivered}
+	}
+	return nil
+}
+
+// UndeliveredError is returned by a shutdown leaving messages undelivered
+type UndeliveredError struct {
+	// Undelivered counts the undelivered messages by endpoint address
+	Undelivered map[string]int
+}
+
+func (e *UndeliveredError) Error() string {
+	addresses := make([]string, 0, len(e.Undelivered))
+	for address := range e.Undelivered {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	counts := make([]string, len(addresses))
+	for i, address := range addresses {
+		counts[i] = fmt.Sprintf("%v: %v", address, e.Undelivered[address])
+	}
+	return "remote: undelivered messages, " + strings.Join(counts, ", ")
+}
+
+// Shutdown the remote server
+func (r *Remote) Shutdown(graceful bool) {
+	if graceful {
+		r.edpReader.suspend(true)
+		r.stopEndpointManager()
+		r.stopActivatorActor()
 	}
+	r.config.transport.Stop(graceful)
 }
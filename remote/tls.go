@@ -29,53 +29,41 @@ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
 See the License for the specific language governing permissions and
 limitations under the License.
 *****************************************************/
-
-package zmqremote
+package remote
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
-	"reflect"
-
-	"github.com/gogo/protobuf/proto"
+	"io/ioutil"
 )
 
-type protoSerializer struct{}
-
-func newProtoSerializer() Serializer {
-	return &protoSerializer{}
-}
-
-func (protoSerializer) Serialize(msg interface{}) ([]byte, error) {
-	if message, ok := msg.(proto.Message); ok {
-		bytes, err := proto.Marshal(message)
-		if err != nil {
-			return nil, err
-		}
-
-		return bytes, nil
+// loadTLS returns the TLS configurations of the server and the client of a node authenticating each other,
+// both present the certificate in certFile and keyFile and require the certificate of the peer to be signed by the CA in caFile
+func loadTLS(certFile, keyFile, caFile string) (*tls.Config, *tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, nil, err
 	}
-	return nil, fmt.Errorf("msg must be proto.Message")
-}
-
-func (protoSerializer) Deserialize(typeName string, bytes []byte) (interface{}, error) {
-	protoType := proto.MessageType(typeName)
-	if protoType == nil {
-		return nil, fmt.Errorf("Unknown message type %v", typeName)
+	ca, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, nil, fmt.Errorf("remote: no certificate found in %v", caFile)
 	}
-	t := protoType.Elem()
-
-	intPtr := reflect.New(t)
-	instance := intPtr.Interface().(proto.Message)
-	proto.Unmarshal(bytes, instance)
-
-	return instance, nil
-}
-
-func (protoSerializer) GetTypeName(msg interface{}) (string, error) {
-	if message, ok := msg.(proto.Message); ok {
-		typeName := proto.MessageName(message)
 
-		return typeName, nil
+	server := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+		MinVersion:   tls.VersionTLS12,
+	}
+	client := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS12,
 	}
-	return "", fmt.Errorf("msg must be proto.Message")
+	return server, client, nil
 }
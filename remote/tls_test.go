@@ -0,0 +1,251 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package remote
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+	"github.com/OnyxPay/OnyxChain-eventbus/actor/actortest"
+	"github.com/stretchr/testify/assert"
+)
+
+// testCA is a certificate authority issuing the certificates of the test nodes
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	dir  string
+	file string
+}
+
+func newTestCA(t *testing.T, dir, name string) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca := &testCA{cert: cert, key: key, dir: dir, file: filepath.Join(dir, name+".pem")}
+	writePEM(t, ca.file, "CERTIFICATE", der)
+	return ca
+}
+
+// issue writes a certificate of the CA for a node on the loopback address, it returns the certificate and key files
+func (ca *testCA) issue(t *testing.T, name string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile := filepath.Join(ca.dir, name+".crt")
+	keyFile := filepath.Join(ca.dir, name+".key")
+	writePEM(t, certFile, "CERTIFICATE", der)
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+	return certFile, keyFile
+}
+
+func writePEM(t *testing.T, file, blockType string, der []byte) {
+	if err := ioutil.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStartSystem_TLSConfiguration(t *testing.T) {
+	dir, err := ioutil.TempDir("", "remote")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ca := newTestCA(t, dir, "ca")
+	certFile, keyFile := ca.issue(t, "node1")
+
+	for _, options := range [][]RemotingOption{
+		{WithTLS("", "", ca.file)},
+		{WithTLS("", keyFile, "")},
+		{WithTLS(certFile, keyFile, "")},
+		{WithTLS(certFile, "", ca.file)},
+		{WithTLS(certFile, keyFile, filepath.Join(dir, "missing.pem"))},
+		{WithTLS(certFile, keyFile, keyFile)},
+	} {
+		system := actor.NewSystem(actor.Config{})
+		r, err := StartSystem(system, "127.0.0.1:0", options...)
+		assert.Error(t, err)
+		assert.Nil(t, r)
+		system.Shutdown()
+	}
+}
+
+func TestStartSystem_MutualTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "remote")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ca := newTestCA(t, dir, "ca")
+	certFile, keyFile := ca.issue(t, "node1")
+
+	var mu sync.Mutex
+	var peers []*Peer
+	authorize := func(peer *Peer, sender *actor.PID, target *actor.PID, typeName string) bool {
+		mu.Lock()
+		peers = append(peers, peer)
+		mu.Unlock()
+		return true
+	}
+	server := actor.NewSystem(actor.Config{})
+	defer server.Shutdown()
+	serverRemote, err := StartSystem(server, "127.0.0.1:0", WithTLS(certFile, keyFile, ca.file), WithAuthorizer(authorize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer serverRemote.Shutdown(false)
+	client := actor.NewSystem(actor.Config{})
+	defer client.Shutdown()
+	clientRemote, err := StartSystem(client, "127.0.0.1:0", WithTLS(certFile, keyFile, ca.file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clientRemote.Shutdown(false)
+
+	probe := actortest.NewTestProbe(t, server)
+	defer probe.Stop()
+	client.NewPID(probe.PID().Address, probe.PID().Id).Tell(&ActorPidRequest{Name: "tls"})
+	probe.ExpectMsg(&ActorPidRequest{Name: "tls"}, 5*time.Second)
+
+	//the peer is identified by its verified certificate
+	mu.Lock()
+	defer mu.Unlock()
+	if assert.Len(t, peers, 1) {
+		assert.Equal(t, "node1", peers[0].Identity)
+		assert.Equal(t, "node1", peers[0].Certificate.Subject.CommonName)
+	}
+}
+
+func TestStartSystem_UntrustedCertificate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "remote")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ca := newTestCA(t, dir, "ca")
+	certFile, keyFile := ca.issue(t, "node1")
+	untrusted := newTestCA(t, dir, "untrusted")
+	untrustedCert, untrustedKey := untrusted.issue(t, "intruder")
+
+	server := actor.NewSystem(actor.Config{})
+	defer server.Shutdown()
+	serverRemote, err := StartSystem(server, "127.0.0.1:0", WithTLS(certFile, keyFile, ca.file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer serverRemote.Shutdown(false)
+
+	//the client trusts the server, the server does not trust the client certificate
+	cert, err := tls.LoadX509KeyPair(untrustedCert, untrustedKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	conn, err := tls.Dial("tcp", server.ProcessRegistry.Address, &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      roots,
+		NextProtos:   []string{"h2"},
+	})
+	if err == nil {
+		defer conn.Close()
+		//with TLS 1.3 the client learns the server rejected its certificate on reading
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		_, err = conn.Read(make([]byte, 1))
+	}
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "certificate")
+	}
+
+	//a node with the untrusted certificate cannot deliver messages
+	intruder := actor.NewSystem(actor.Config{})
+	defer intruder.Shutdown()
+	intruderRemote, err := StartSystem(intruder, "127.0.0.1:0", WithTLS(untrustedCert, untrustedKey, ca.file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer intruderRemote.Shutdown(false)
+	probe := actortest.NewTestProbe(t, server)
+	defer probe.Stop()
+	intruder.NewPID(probe.PID().Address, probe.PID().Id).Tell(&ActorPidRequest{Name: "intruder"})
+	probe.ExpectNoMsg(500 * time.Millisecond)
+}
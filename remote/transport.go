@@ -0,0 +1,78 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package remote
+
+import "crypto/x509"
+
+// Transport is the socket layer of the remoting, it carries message batches between the nodes.
+// The endpoints, watchers, activator and serializers are shared by all the transports
+type Transport interface {
+	// Listen starts receiving the batches sent to address and passes them to receiver,
+	// it returns the address actually listened on
+	Listen(address string, receiver BatchReceiver) (string, error)
+	// Dial connects to the node listening on address
+	Dial(address string) (Connection, error)
+	// Stop stops listening, a graceful stop lets the pending batches be received
+	Stop(graceful bool)
+}
+
+// BatchReceiver is the receiving side of the remoting, a Transport passes it the batches it receives
+type BatchReceiver interface {
+	// Accepting reports whether batches are accepted, a Transport should refuse connections while they are not
+	Accepting() bool
+	// ReceiveBatch delivers the messages of a batch received from peer to their local targets,
+	// a Transport should close the connection the batch came from when it returns an error
+	ReceiveBatch(peer *Peer, batch *MessageBatch) error
+}
+
+// Peer is the node a batch was received from, as authenticated by the Transport
+type Peer struct {
+	// Identity is the verified identity of the node: the common name of its certificate with mutual TLS,
+	// its Z85 encoded public key with CURVE. It is empty when the connection is not authenticated
+	Identity string
+	// Certificate is the verified TLS certificate of the node, nil without mutual TLS
+	Certificate *x509.Certificate
+}
+
+// Connection is a connection of a Transport to another node
+type Connection interface {
+	// SendBatch sends a batch to the node
+	SendBatch(batch *MessageBatch) error
+	// Heartbeat sends a heartbeat to the node, it returns how many heartbeats the node answered since the previous call
+	Heartbeat() (int, error)
+	// DefaultSerializerID returns the serializer the node expects for messages sent without one
+	DefaultSerializerID() int32
+	// Lost is closed once the connection is lost or closed
+	Lost() <-chan struct{}
+	// Close closes the connection
+	Close() error
+}
@@ -0,0 +1,324 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package remotetest
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+	"github.com/OnyxPay/OnyxChain-eventbus/log"
+	"github.com/OnyxPay/OnyxChain-eventbus/remote"
+	"google.golang.org/grpc"
+)
+
+// Transport is the remoting the nodes of a Cluster use
+type Transport int
+
+const (
+	// GRPC runs the nodes on the remote package
+	GRPC Transport = iota
+	// ZMQ runs the nodes on the ZeroMQ transport of the zmqremote package, it needs the zmq build tag and libzmq
+	ZMQ
+)
+
+// starters start the remoting of a node, by the transports compiled in
+var starters = map[Transport]func(n *Node) (*remote.Remote, error){
+	GRPC: startGRPC,
+}
+
+// Transports returns the transports compiled in, GRPC first
+func Transports() []Transport {
+	transports := make([]Transport, 0, len(starters))
+	for transport := GRPC; transport <= ZMQ; transport++ {
+		if starters[transport] != nil {
+			transports = append(transports, transport)
+		}
+	}
+	return transports
+}
+
+func (t Transport) String() string {
+	switch t {
+	case GRPC:
+		return "grpc"
+	case ZMQ:
+		return "zmq"
+	}
+	return fmt.Sprintf("Transport(%d)", int(t))
+}
+
+// Option configures the nodes of a Cluster
+type Option func(*Cluster)
+
+// WithRemotingOptions starts the remoting of every node with options, such as the endpoint writer backoff
+func WithRemotingOptions(options ...remote.RemotingOption) Option {
+	return func(c *Cluster) {
+		c.options = append(c.options, options...)
+	}
+}
+
+// WithClock makes the actor systems of the nodes use clock, such as an actortest.VirtualClock
+func WithClock(clock actor.Clock) Option {
+	return func(c *Cluster) {
+		c.clock = clock
+	}
+}
+
+// Cluster is a set of nodes running in the process, connected through links that can be cut
+type Cluster struct {
+	transport Transport
+	nodes     []*Node
+	options   []remote.RemotingOption
+	clock     actor.Clock
+
+	mu    sync.Mutex
+	links map[[2]int]*link
+	cut   map[[2]int]bool
+}
+
+// Node is a member of a Cluster, with its own actor system and remoting
+type Node struct {
+	// Address is the address of the node, and of the PIDs of its actors
+	Address string
+	// System is the actor system of the node
+	System *actor.ActorSystem
+
+	cluster *Cluster
+	index   int
+	remote  *remote.Remote
+}
+
+// NewCluster starts n nodes on loopback ports
+func NewCluster(transport Transport, n int, options ...Option) (*Cluster, error) {
+	if starters[transport] == nil {
+		return nil, fmt.Errorf("remotetest: transport %v is not compiled in", transport)
+	}
+	c := &Cluster{
+		transport: transport,
+		links:     make(map[[2]int]*link),
+		cut:       make(map[[2]int]bool),
+	}
+	for _, option := range options {
+		option(c)
+	}
+	for i := 0; i < n; i++ {
+		address, err := freeAddress()
+		if err != nil {
+			return nil, err
+		}
+		c.nodes = append(c.nodes, &Node{
+			Address: address,
+			System:  actor.NewSystem(actor.Config{Clock: c.clock}),
+			cluster: c,
+			index:   i,
+		})
+	}
+	for _, node := range c.nodes {
+		if err := node.start(); err != nil {
+			c.Shutdown()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// freeAddress returns a loopback address with a port free at the time of the call
+func freeAddress() (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer listener.Close()
+	return listener.Addr().String(), nil
+}
+
+// Transport returns the remoting of the nodes
+func (c *Cluster) Transport() Transport {
+	return c.transport
+}
+
+// Node returns the i-th node
+func (c *Cluster) Node(i int) *Node {
+	return c.nodes[i]
+}
+
+// Nodes returns the nodes of the cluster
+func (c *Cluster) Nodes() []*Node {
+	return c.nodes
+}
+
+// CutLink fails the connections from node from to node to, and refuses new ones until the link heals
+func (c *Cluster) CutLink(from, to int) {
+	c.setCut(from, to, true)
+}
+
+// HealLink lets node from connect to node to again
+func (c *Cluster) HealLink(from, to int) {
+	c.setCut(from, to, false)
+}
+
+// Partition cuts the links between the groups of nodes in both directions, the nodes in no group form one more group
+func (c *Cluster) Partition(groups ...[]int) {
+	group := make([]int, len(c.nodes))
+	for i := range group {
+		group[i] = len(groups)
+	}
+	for g, nodes := range groups {
+		for _, i := range nodes {
+			group[i] = g
+		}
+	}
+	for from := range c.nodes {
+		for to := range c.nodes {
+			if group[from] != group[to] {
+				c.CutLink(from, to)
+			}
+		}
+	}
+}
+
+// Heal heals all the links
+func (c *Cluster) Heal() {
+	for from := range c.nodes {
+		for to := range c.nodes {
+			c.HealLink(from, to)
+		}
+	}
+}
+
+// Shutdown stops the nodes and their links
+func (c *Cluster) Shutdown() {
+	for _, node := range c.nodes {
+		node.shutdown()
+	}
+	c.mu.Lock()
+	for _, l := range c.links {
+		l.close()
+	}
+	c.links = make(map[[2]int]*link)
+	c.mu.Unlock()
+}
+
+func (c *Cluster) setCut(from, to int, cut bool) {
+	key := [2]int{from, to}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cut[key] = cut
+	if l, ok := c.links[key]; ok {
+		l.setCut(cut)
+	}
+}
+
+// route returns the address node from dials to reach address
+func (c *Cluster) route(from int, address string) string {
+	to := -1
+	for _, node := range c.nodes {
+		if node.Address == address {
+			to = node.index
+		}
+	}
+	if to == -1 || to == from {
+		return address
+	}
+
+	key := [2]int{from, to}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	l, ok := c.links[key]
+	if !ok {
+		var err error
+		if l, err = newLink(address); err != nil {
+			plog.Error("failed to create link", log.String("address", address), log.Error(err))
+			return address
+		}
+		l.setCut(c.cut[key])
+		c.links[key] = l
+	}
+	return l.address()
+}
+
+func (n *Node) start() error {
+	r, err := starters[n.cluster.transport](n)
+	if err != nil {
+		return err
+	}
+	n.remote = r
+	return nil
+}
+
+func startGRPC(n *Node) (*remote.Remote, error) {
+	dialer := func(address string, timeout time.Duration) (net.Conn, error) {
+		return net.DialTimeout("tcp", n.dialAddress(address), timeout)
+	}
+	options := append([]remote.RemotingOption{remote.WithDialOptions(grpc.WithDialer(dialer))}, n.cluster.options...)
+	return remote.StartSystem(n.System, n.Address, options...)
+}
+
+// dialAddress returns the address the node dials to reach address
+func (n *Node) dialAddress(address string) string {
+	return n.cluster.route(n.index, address)
+}
+
+func (n *Node) shutdown() {
+	if n.remote != nil {
+		n.remote.Shutdown(false)
+	}
+	n.System.Shutdown()
+}
+
+// Remote returns the remoting of the node
+func (n *Node) Remote() *remote.Remote {
+	return n.remote
+}
+
+// Index returns the position of the node in the cluster
+func (n *Node) Index() int {
+	return n.index
+}
+
+// Spawn starts an actor on the node
+func (n *Node) Spawn(props *actor.Props) *actor.PID {
+	return n.System.Spawn(props)
+}
+
+// SpawnNamed starts a named actor on the node
+func (n *Node) SpawnNamed(props *actor.Props, name string) (*actor.PID, error) {
+	return n.System.SpawnNamed(props, name)
+}
+
+// PID returns pid as seen from the node, messages to it are sent through the remoting of the node
+func (n *Node) PID(pid *actor.PID) *actor.PID {
+	return n.System.NewPID(pid.Address, pid.Id)
+}
@@ -0,0 +1,112 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package remotetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/remote"
+)
+
+func TestCluster(t *testing.T) {
+	for _, transport := range Transports() {
+		t.Run(transport.String(), func(t *testing.T) {
+			testCluster(t, transport)
+		})
+	}
+}
+
+func testCluster(t *testing.T, transport Transport) {
+	cluster, err := NewCluster(transport, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cluster.Shutdown()
+
+	probe := cluster.Node(1).NewProbe(t)
+	target := cluster.Node(0).PID(probe.PID())
+
+	target.Tell(&remote.ActorPidRequest{Name: "before"})
+	probe.ExpectMsg(&remote.ActorPidRequest{Name: "before"}, 5*time.Second)
+
+	cluster.Partition([]int{0})
+	target.Tell(&remote.ActorPidRequest{Name: "partitioned"})
+	probe.ExpectNoMsg(time.Second)
+
+	// the third node is on the other side of the partition
+	cluster.Node(2).PID(probe.PID()).Tell(&remote.ActorPidRequest{Name: "same side"})
+	probe.ExpectMsg(&remote.ActorPidRequest{Name: "same side"}, 5*time.Second)
+
+	cluster.Heal()
+	Eventually(t, probe, func() {
+		target.Tell(&remote.ActorPidRequest{Name: "healed"})
+	}, 10*time.Second)
+}
+
+func TestPartitionPayloadPID(t *testing.T) {
+	for _, transport := range Transports() {
+		t.Run(transport.String(), func(t *testing.T) {
+			testPartitionPayloadPID(t, transport)
+		})
+	}
+}
+
+// a PID received in a message is resolved by the receiving node, the partition applies to it too
+func testPartitionPayloadPID(t *testing.T, transport Transport) {
+	cluster, err := NewCluster(transport, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cluster.Shutdown()
+
+	target := cluster.Node(1).NewProbe(t)
+	responder := cluster.Node(1).NewProbe(t)
+	client := cluster.Node(0).NewProbe(t)
+
+	client.Request(cluster.Node(0).PID(responder.PID()), &remote.ActorPidRequest{Name: "target"})
+	responder.ExpectMsg(&remote.ActorPidRequest{Name: "target"}, 5*time.Second)
+	responder.Reply(&remote.ActorPidResponse{Pid: target.PID()})
+	pid := client.ExpectMsgType((*remote.ActorPidResponse)(nil), 5*time.Second).(*remote.ActorPidResponse).Pid
+
+	pid.Tell(&remote.ActorPidRequest{Name: "before"})
+	target.ExpectMsg(&remote.ActorPidRequest{Name: "before"}, 5*time.Second)
+
+	cluster.Partition([]int{0})
+	pid.Tell(&remote.ActorPidRequest{Name: "partitioned"})
+	target.ExpectNoMsg(time.Second)
+
+	cluster.Heal()
+	Eventually(t, target, func() {
+		pid.Tell(&remote.ActorPidRequest{Name: "healed"})
+	}, 10*time.Second)
+}
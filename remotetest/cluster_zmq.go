@@ -1,3 +1,6 @@
+//go:build zmq
+// +build zmq
+
 /****************************************************
 Copyright 2019 The OnyxChain-eventbus Authors
 
@@ -29,37 +32,19 @@ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
 See the License for the specific language governing permissions and
 limitations under the License.
 *****************************************************/
-package zmqremote
+package remotetest
 
-var DefaultSerializerID int32 = 0
-var serializers []Serializer
+import (
+	"github.com/OnyxPay/OnyxChain-eventbus/remote"
+	"github.com/OnyxPay/OnyxChain-eventbus/zmqremote"
+)
 
 func init() {
-	RegisterSerializer(newProtoSerializer())
-	RegisterSerializer(newJsonSerializer())
-}
-
-func RegisterSerializerAsDefault(serializer Serializer) {
-	serializers = append(serializers, serializer)
-	DefaultSerializerID = int32(len(serializers) - 1)
-}
-
-func RegisterSerializer(serializer Serializer) {
-	serializers = append(serializers, serializer)
-}
-
-type Serializer interface {
-	Serialize(msg interface{}) ([]byte, error)
-	Deserialize(typeName string, bytes []byte) (interface{}, error)
-	GetTypeName(msg interface{}) (string, error)
-}
-
-func Serialize(message interface{}, serializerID int32) ([]byte, string, error) {
-	res, err := serializers[serializerID].Serialize(message)
-	typeName, err := serializers[serializerID].GetTypeName(message)
-	return res, typeName, err
+	starters[ZMQ] = startZMQ
 }
 
-func Deserialize(message []byte, typeName string, serializerID int32) (interface{}, error) {
-	return serializers[serializerID].Deserialize(typeName, message)
+func startZMQ(n *Node) (*remote.Remote, error) {
+	transport := zmqremote.NewTransport(zmqremote.WithDialAddress(n.dialAddress))
+	options := append([]remote.RemotingOption{remote.WithTransport(transport)}, n.cluster.options...)
+	return remote.StartSystem(n.System, n.Address, options...)
 }
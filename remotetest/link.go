@@ -0,0 +1,140 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package remotetest
+
+import (
+	"io"
+	"net"
+	"sync"
+)
+
+// link proxies the connections from one node to another
+type link struct {
+	target   string
+	listener net.Listener
+
+	mu    sync.Mutex
+	cut   bool
+	conns map[net.Conn]struct{}
+}
+
+func newLink(target string) (*link, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	l := &link{
+		target:   target,
+		listener: listener,
+		conns:    make(map[net.Conn]struct{}),
+	}
+	go l.accept()
+	return l, nil
+}
+
+// address returns the address the node dials instead of the target
+func (l *link) address() string {
+	return l.listener.Addr().String()
+}
+
+func (l *link) accept() {
+	for {
+		conn, err := l.listener.Accept()
+		if err != nil {
+			return
+		}
+		go l.forward(conn)
+	}
+}
+
+func (l *link) forward(conn net.Conn) {
+	if !l.track(conn) {
+		conn.Close()
+		return
+	}
+	defer l.untrack(conn)
+
+	upstream, err := net.Dial("tcp", l.target)
+	if err != nil {
+		return
+	}
+	if !l.track(upstream) {
+		upstream.Close()
+		return
+	}
+	defer l.untrack(upstream)
+
+	done := make(chan struct{}, 2)
+	pipe := func(dst, src net.Conn) {
+		io.Copy(dst, src)
+		done <- struct{}{}
+	}
+	go pipe(upstream, conn)
+	go pipe(conn, upstream)
+	<-done
+}
+
+// track registers an open connection, it returns false if the link is cut
+func (l *link) track(conn net.Conn) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.cut {
+		return false
+	}
+	l.conns[conn] = struct{}{}
+	return true
+}
+
+func (l *link) untrack(conn net.Conn) {
+	l.mu.Lock()
+	delete(l.conns, conn)
+	l.mu.Unlock()
+	conn.Close()
+}
+
+// setCut cuts the link, closing its connections and refusing new ones, or heals it
+func (l *link) setCut(cut bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cut = cut
+	if !cut {
+		return
+	}
+	for conn := range l.conns {
+		conn.Close()
+	}
+}
+
+func (l *link) close() {
+	l.listener.Close()
+	l.setCut(true)
+}
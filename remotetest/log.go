@@ -29,18 +29,20 @@ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
 See the License for the specific language governing permissions and
 limitations under the License.
 *****************************************************/
-package zmqremote
 
-type ResponseStatusCode int32
+package remotetest
 
-const (
-	ResponseStatusCodeOK ResponseStatusCode = iota
-	ResponseStatusCodeUNAVAILABLE
-	ResponseStatusCodeTIMEOUT
-	ResponseStatusCodePROCESSNAMEALREADYEXIST
-	ResponseStatusCodeERROR
+import (
+	"github.com/OnyxPay/OnyxChain-eventbus/log"
 )
 
-func (c ResponseStatusCode) ToInt32() int32 {
-	return int32(c)
+var (
+	plog = log.New(log.DebugLevel, "[REMOTETEST]")
+)
+
+// SetLogLevel sets the log level for the logger.
+//
+// SetLogLevel is safe to call concurrently
+func SetLogLevel(level log.Level) {
+	plog.SetLevel(level)
 }
@@ -0,0 +1,59 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package remotetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor/actortest"
+)
+
+// NewProbe spawns an actortest.TestProbe on the node, use Node.PID to reach it from another node
+func (n *Node) NewProbe(t testing.TB) *actortest.TestProbe {
+	return actortest.NewTestProbe(t, n.System)
+}
+
+// Eventually sends messages with send until the probe receives one within the timeout, it returns the received message.
+// It helps after a link healed, when the first messages may be lost while the nodes reconnect
+func Eventually(t testing.TB, probe *actortest.TestProbe, send func(), timeout time.Duration) interface{} {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		send()
+		if msg, ok := probe.Receive(100 * time.Millisecond); ok {
+			return msg
+		}
+	}
+	t.Fatalf("expected a delivery within %v", timeout)
+	return nil
+}
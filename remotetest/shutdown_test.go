@@ -0,0 +1,146 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package remotetest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor/actortest"
+	"github.com/OnyxPay/OnyxChain-eventbus/remote"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShutdownDrain(t *testing.T) {
+	for _, transport := range Transports() {
+		t.Run(transport.String(), func(t *testing.T) {
+			cluster, err := NewCluster(transport, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer cluster.Shutdown()
+
+			probe := cluster.Node(1).NewProbe(t)
+			target := cluster.Node(0).PID(probe.PID())
+			for i := 0; i < 100; i++ {
+				target.Tell(&remote.ActorPidRequest{Name: fmt.Sprint(i)})
+			}
+			assert.NoError(t, cluster.Node(0).Remote().ShutdownTimeout(true, 10*time.Second))
+			for i := 0; i < 100; i++ {
+				probe.ExpectMsg(&remote.ActorPidRequest{Name: fmt.Sprint(i)}, 5*time.Second)
+			}
+		})
+	}
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	for _, transport := range Transports() {
+		t.Run(transport.String(), func(t *testing.T) {
+			clock := actortest.NewVirtualClock(time.Now())
+			cluster, err := NewCluster(transport, 2, WithClock(clock), WithRemotingOptions(remote.WithEndpointWriterMaxRetries(1000)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer cluster.Shutdown()
+
+			cluster.CutLink(0, 1)
+			probe := cluster.Node(1).NewProbe(t)
+			target := cluster.Node(0).PID(probe.PID())
+			for i := 0; i < 5; i++ {
+				target.Tell(&remote.ActorPidRequest{Name: fmt.Sprint(i)})
+			}
+
+			done := make(chan error, 1)
+			go func() {
+				done <- cluster.Node(0).Remote().ShutdownTimeout(true, time.Second)
+			}()
+			var err2 error
+		wait:
+			for {
+				select {
+				case err2 = <-done:
+					break wait
+				case <-time.After(time.Millisecond):
+					clock.Advance(100 * time.Millisecond)
+				}
+			}
+			undelivered, ok := err2.(*remote.UndeliveredError)
+			if !ok {
+				t.Fatalf("expected an UndeliveredError, got %v", err2)
+			}
+			assert.Equal(t, map[string]int{cluster.Node(1).Address: 5}, undelivered.Undelivered)
+			assert.Equal(t, "remote: undelivered messages, "+cluster.Node(1).Address+": 5", undelivered.Error())
+			probe.ExpectNoMsg(100 * time.Millisecond)
+		})
+	}
+}
+
+func TestShutdownAfterEndpointTerminated(t *testing.T) {
+	for _, transport := range Transports() {
+		t.Run(transport.String(), func(t *testing.T) {
+			cluster, err := NewCluster(transport, 2, WithRemotingOptions(remote.WithEndpointWriterMaxRetries(1)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer cluster.Shutdown()
+
+			terminated := make(chan struct{}, 1)
+			sub := cluster.Node(0).System.EventStream().SubscribeType((*remote.EndpointTerminatedEvent)(nil), func(evt interface{}) {
+				select {
+				case terminated <- struct{}{}:
+				default:
+				}
+			})
+			defer cluster.Node(0).System.EventStream().Unsubscribe(sub)
+
+			cluster.CutLink(0, 1)
+			probe := cluster.Node(1).NewProbe(t)
+			target := cluster.Node(0).PID(probe.PID())
+			for i := 0; i < 5; i++ {
+				target.Tell(&remote.ActorPidRequest{Name: "dropped"})
+			}
+			select {
+			case <-terminated:
+			case <-time.After(10 * time.Second):
+				t.Fatal("expected the endpoint to terminate")
+			}
+
+			//the messages dropped by the terminated endpoint are not waited for nor counted by the next one
+			cluster.HealLink(0, 1)
+			Eventually(t, probe, func() {
+				target.Tell(&remote.ActorPidRequest{Name: "healed"})
+			}, 10*time.Second)
+			assert.NoError(t, cluster.Node(0).Remote().ShutdownTimeout(true, 10*time.Second))
+		})
+	}
+}
@@ -53,7 +53,7 @@ func (ref *process) SendUserMessage(pid *actor.PID, message interface{}) {
 	if _, ok := msg.(ManagementMessage); !ok {
 		ref.state.RouteMessage(message)
 	} else {
-		r, _ := actor.ProcessRegistry.Get(ref.router)
+		r, _ := ref.router.System().ProcessRegistry.Get(ref.router)
 		// Always send the original message to the router actor,
 		// since if the message is enveloped, the sender need to get a response.
 		r.SendUserMessage(pid, message)
@@ -64,7 +64,7 @@ func (ref *process) SendSystemMessage(pid *actor.PID, message interface{}) {
 	switch msg := message.(type) {
 	case *actor.Watch:
 		if atomic.LoadInt32(&ref.stopping) == 1 {
-			if r, ok := actor.ProcessRegistry.Get(msg.Watcher); ok {
+			if r, ok := msg.Watcher.System().ProcessRegistry.Get(msg.Watcher); ok {
 				r.SendSystemMessage(msg.Watcher, &actor.Terminated{Who: pid})
 			}
 			return
@@ -82,14 +82,14 @@ func (ref *process) SendSystemMessage(pid *actor.PID, message interface{}) {
 		term := &actor.Terminated{Who: pid}
 		ref.mu.Lock()
 		ref.watchers.ForEach(func(_ int, other actor.PID) {
-			if r, ok := actor.ProcessRegistry.Get(&other); ok {
+			if r, ok := other.System().ProcessRegistry.Get(&other); ok {
 				r.SendSystemMessage(&other, term)
 			}
 		})
 		ref.mu.Unlock()
 
 	default:
-		r, _ := actor.ProcessRegistry.Get(ref.router)
+		r, _ := ref.router.System().ProcessRegistry.Get(ref.router)
 		r.SendSystemMessage(pid, message)
 
 	}
@@ -101,6 +101,6 @@ func (ref *process) Stop(pid *actor.PID) {
 	}
 
 	ref.router.StopFuture().Wait()
-	actor.ProcessRegistry.Remove(pid)
+	pid.System().ProcessRegistry.Remove(pid)
 	ref.SendSystemMessage(pid, &actor.Stop{})
 }
@@ -39,7 +39,8 @@ import (
 
 func spawn(id string, config RouterConfig, props *actor.Props, parent *actor.PID) (*actor.PID, error) {
 	ref := &process{}
-	proxy, absent := actor.ProcessRegistry.Add(ref, id)
+	system := props.System()
+	proxy, absent := system.ProcessRegistry.Add(ref, id)
 	if !absent {
 		return proxy, actor.ErrNameExists
 	}
@@ -58,7 +59,7 @@ func spawn(id string, config RouterConfig, props *actor.Props, parent *actor.PID
 				state:  ref.state,
 				wg:     wg,
 			}
-		}), parent)
+		}).WithSystem(system), parent)
 		wg.Wait() // wait for routerActor to start
 	} else {
 		wg := &sync.WaitGroup{}
@@ -70,7 +71,7 @@ func spawn(id string, config RouterConfig, props *actor.Props, parent *actor.PID
 				state:  ref.state,
 				wg:     wg,
 			}
-		}), parent)
+		}).WithSystem(system), parent)
 		wg.Wait() // wait for routerActor to start
 	}
 
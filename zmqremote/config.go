@@ -0,0 +1,120 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package zmqremote
+
+import (
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/eventstream"
+	"github.com/OnyxPay/OnyxChain-eventbus/remote"
+)
+
+// defaultSendTimeout is how long sending a batch waits by default
+const defaultSendTimeout = 5 * time.Second
+
+// RemotingOption configures how the remote infrastructure is started
+type RemotingOption func(*remoteConfig)
+
+func newRemoteConfig(options []RemotingOption) *remoteConfig {
+	config := &remoteConfig{
+		sendTimeout: defaultSendTimeout,
+	}
+	for _, option := range options {
+		option(config)
+	}
+	return config
+}
+
+// WithEventStream sets the EventStream endpoint events are published on, it defaults to the one of the actor system
+func WithEventStream(stream *eventstream.EventStream) RemotingOption {
+	return func(config *remoteConfig) {
+		config.eventStream = stream
+	}
+}
+
+// WithDialAddress maps the address of a node to the address the endpoint writer connects to, such as a proxy
+func WithDialAddress(dialAddress func(address string) string) RemotingOption {
+	return func(config *remoteConfig) {
+		config.dialAddress = dialAddress
+	}
+}
+
+// WithCurve secures the connections with ZeroMQ CURVE, the node uses the Z85 encoded publicKey and secretKey.
+// peers maps the address of every other node to its public key: it is the allow-list of the nodes
+// that may connect, and the key a node is dialed with. A node missing from peers can neither connect nor be dialed
+func WithCurve(publicKey, secretKey string, peers map[string]string) RemotingOption {
+	return func(config *remoteConfig) {
+		config.curve = &curveConfig{
+			publicKey: publicKey,
+			secretKey: secretKey,
+			peers:     peers,
+		}
+	}
+}
+
+// WithSendTimeout sets how long sending a batch waits for the node to be connected and to have room for it,
+// the endpoint writer then buffers the batch and reconnects. It defaults to 5 seconds
+func WithSendTimeout(timeout time.Duration) RemotingOption {
+	return func(config *remoteConfig) {
+		config.sendTimeout = timeout
+	}
+}
+
+// WithRemotingOptions applies options of the remote package, such as the endpoint queue sizes
+func WithRemotingOptions(options ...remote.RemotingOption) RemotingOption {
+	return func(config *remoteConfig) {
+		config.options = append(config.options, options...)
+	}
+}
+
+type remoteConfig struct {
+	eventStream *eventstream.EventStream
+	dialAddress func(address string) string
+	curve       *curveConfig
+	sendTimeout time.Duration
+	options     []remote.RemotingOption
+}
+
+type curveConfig struct {
+	publicKey string
+	secretKey string
+	peers     map[string]string
+}
+
+// remotingOptions returns the options starting the remote package over ZeroMQ
+func (config *remoteConfig) remotingOptions() []remote.RemotingOption {
+	options := append([]remote.RemotingOption{remote.WithTransport(newTransport(config))}, config.options...)
+	if config.eventStream != nil {
+		options = append(options, remote.WithEventStream(config.eventStream))
+	}
+	return options
+}
@@ -1,198 +0,0 @@
-/****************************************************
-Copyright 2019 The OnyxChain-eventbus Authors
-
-Licensed under the Apache License, Version 2.0 (the "License");
-you may not use this file except in compliance with the License.
-You may obtain a copy of the License at
-
-    http://www.apache.org/licenses/LICENSE-2.0
-
-Unless required by applicable law or agreed to in writing, software
-distributed under the License is distributed on an "AS IS" BASIS,
-WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-See the License for the specific language governing permissions and
-limitations under the License.
-*****************************************************/
-
-/***************************************************
-Copyright 2016 https://github.com/AsynkronIT/protoactor-go
-
-Licensed under the Apache License, Version 2.0 (the "License");
-you may not use this file except in compliance with the License.
-You may obtain a copy of the License at
-
-    http://www.apache.org/licenses/LICENSE-2.0
-
-Unless required by applicable law or agreed to in writing, software
-distributed under the License is distributed on an "AS IS" BASIS,
-WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-See the License for the specific language governing permissions and
-limitations under the License.
-*****************************************************/
-package zmqremote
-
-import (
-	"sync"
-	"sync/atomic"
-
-	"github.com/OnyxPay/OnyxChain-eventbus/actor"
-	"github.com/OnyxPay/OnyxChain-eventbus/eventstream"
-	"github.com/OnyxPay/OnyxChain-eventbus/mailbox"
-)
-
-var endpointManager *endpointManagerValue
-
-type endpointLazy struct {
-	valueFunc func() *endpoint
-	unloaded  uint32
-}
-
-type endpoint struct {
-	writer  *actor.PID
-	watcher *actor.PID
-}
-
-type endpointManagerValue struct {
-	connections *sync.Map
-	//config             *remoteConfig
-	endpointSupervisor *actor.PID
-	endpointSub        *eventstream.Subscription
-}
-
-func startEndpointManager() {
-	plog.Debug("Started EndpointManager")
-	props := actor.FromProducer(newEndpointSupervisor).
-		WithGuardian(actor.RestartingSupervisorStrategy()).
-		WithSupervisor(actor.RestartingSupervisorStrategy()).
-		WithDispatcher(mailbox.NewSynchronizedDispatcher(300))
-	endpointSupervisor, _ := actor.SpawnNamed(props, "EndpointSupervisor")
-
-	endpointManager = &endpointManagerValue{
-		connections: &sync.Map{},
-		//config:             config,
-		endpointSupervisor: endpointSupervisor,
-	}
-
-	endpointManager.endpointSub = eventstream.
-		Subscribe(endpointManager.endpointEvent).
-		WithPredicate(func(m interface{}) bool {
-			switch m.(type) {
-			case *EndpointTerminatedEvent, *EndpointConnectedEvent:
-				return true
-			}
-			return false
-		})
-}
-
-func stopEndpointManager() {
-	eventstream.Unsubscribe(endpointManager.endpointSub)
-	endpointManager.endpointSupervisor.GracefulStop()
-	endpointManager.endpointSub = nil
-	endpointManager.connections = nil
-	plog.Debug("Stopped EndpointManager")
-}
-
-func (em *endpointManagerValue) endpointEvent(evn interface{}) {
-	switch msg := evn.(type) {
-	case *EndpointTerminatedEvent:
-		em.removeEndpoint(msg)
-	case *EndpointConnectedEvent:
-		endpoint := em.ensureConnected(msg.Address)
-		endpoint.watcher.Tell(msg)
-	}
-}
-
-func (em *endpointManagerValue) remoteTerminate(msg *remoteTerminate) {
-	address := msg.Watchee.Address
-	endpoint := em.ensureConnected(address)
-	endpoint.watcher.Tell(msg)
-}
-
-func (em *endpointManagerValue) remoteWatch(msg *remoteWatch) {
-	address := msg.Watchee.Address
-	endpoint := em.ensureConnected(address)
-	endpoint.watcher.Tell(msg)
-}
-
-func (em *endpointManagerValue) remoteUnwatch(msg *remoteUnwatch) {
-	address := msg.Watchee.Address
-	endpoint := em.ensureConnected(address)
-	endpoint.watcher.Tell(msg)
-}
-
-func (em *endpointManagerValue) remoteDeliver(msg *remoteDeliver) {
-	address := msg.target.Address
-	endpoint := em.ensureConnected(address)
-	endpoint.writer.Tell(msg)
-}
-
-func (em *endpointManagerValue) ensureConnected(address string) *endpoint {
-	e, ok := em.connections.Load(address)
-	if !ok {
-		el := &endpointLazy{}
-		var once sync.Once
-		el.valueFunc = func() *endpoint {
-			once.Do(func() {
-				rst, _ := em.endpointSupervisor.RequestFuture(address, -1).Result()
-				ep := rst.(*endpoint)
-				el.valueFunc = func() *endpoint {
-					return ep
-				}
-			})
-			return el.valueFunc()
-		}
-		e, _ = em.connections.LoadOrStore(address, el)
-	}
-
-	el := e.(*endpointLazy)
-	return el.valueFunc()
-}
-
-func (em *endpointManagerValue) removeEndpoint(msg *EndpointTerminatedEvent) {
-	v, ok := em.connections.Load(msg.Address)
-	if ok {
-		le := v.(*endpointLazy)
-		if atomic.CompareAndSwapUint32(&le.unloaded, 0, 1) {
-			em.connections.Delete(msg.Address)
-			ep := le.valueFunc()
-			ep.watcher.Tell(msg)
-			ep.watcher.Stop()
-			ep.writer.Stop()
-		}
-	}
-}
-
-type endpointSupervisor struct{}
-
-func newEndpointSupervisor() actor.Actor {
-	return &endpointSupervisor{}
-}
-
-func (state *endpointSupervisor) Receive(ctx actor.Context) {
-	if address, ok := ctx.Message().(string); ok {
-		e := &endpoint{
-			writer:  state.spawnEndpointWriter(address, ctx),
-			watcher: state.spawnEndpointWatcher(address, ctx),
-		}
-		ctx.Respond(e)
-	}
-}
-
-func (state *endpointSupervisor) HandleFailure(supervisor actor.Supervisor, child *actor.PID, rs *actor.RestartStatistics, reason interface{}, message interface{}) {
-	supervisor.RestartChildren(child)
-}
-
-func (state *endpointSupervisor) spawnEndpointWriter(address string, ctx actor.Context) *actor.PID {
-	props := actor.
-		FromProducer(newEndpointWriter(address)).
-		WithMailbox(newEndpointWriterMailbox(1, 1))
-	pid := ctx.Spawn(props)
-	return pid
-}
-
-func (state *endpointSupervisor) spawnEndpointWatcher(address string, ctx actor.Context) *actor.PID {
-	props := actor.
-		FromProducer(newEndpointWatcher(address))
-	pid := ctx.Spawn(props)
-	return pid
-}
@@ -1,171 +0,0 @@
-/****************************************************
-Copyright 2019 The OnyxChain-eventbus Authors
-
-Licensed under the Apache License, Version 2.0 (the "License");
-you may not use this file except in compliance with the License.
-You may obtain a copy of the License at
-
-    http://www.apache.org/licenses/LICENSE-2.0
-
-Unless required by applicable law or agreed to in writing, software
-distributed under the License is distributed on an "AS IS" BASIS,
-WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-See the License for the specific language governing permissions and
-limitations under the License.
-*****************************************************/
-
-/***************************************************
-Copyright 2016 https://github.com/AsynkronIT/protoactor-go
-
-Licensed under the Apache License, Version 2.0 (the "License");
-you may not use this file except in compliance with the License.
-You may obtain a copy of the License at
-
-    http://www.apache.org/licenses/LICENSE-2.0
-
-Unless required by applicable law or agreed to in writing, software
-distributed under the License is distributed on an "AS IS" BASIS,
-WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-See the License for the specific language governing permissions and
-limitations under the License.
-*****************************************************/
-package zmqremote
-
-import (
-	"time"
-
-	"github.com/OnyxPay/OnyxChain-eventbus/actor"
-	"github.com/OnyxPay/OnyxChain-eventbus/eventstream"
-	"github.com/OnyxPay/OnyxChain-eventbus/log"
-	zmq "github.com/pebbe/zmq4"
-)
-
-func newEndpointWriter(address string) actor.Producer {
-	return func() actor.Actor {
-		return &endpointWriter{
-			address: address,
-		}
-	}
-}
-
-type endpointWriter struct {
-	address             string
-	conn                *zmq.Socket
-	defaultSerializerId int32
-}
-
-func (state *endpointWriter) initialize() {
-	err := state.initializeInternal()
-	if err != nil {
-		plog.Error("EndpointWriter failed to connect", log.String("address", state.address), log.Error(err))
-		time.Sleep(2 * time.Second)
-		panic(err)
-	}
-}
-
-func (state *endpointWriter) initializeInternal() error {
-	plog.Info("Started EndpointWriter", log.String("address", state.address))
-	plog.Info("EndpointWriter connecting", log.String("address", state.address))
-	state.conn, _ = zmq.NewSocket(zmq.DEALER)
-	err := state.conn.Connect("tcp://" + state.address)
-	if err != nil {
-		return err
-	}
-
-	go func() {
-		plog.Info("EndpointWriter connected", log.String("address", state.address))
-		connected := &EndpointConnectedEvent{Address: state.address}
-		eventstream.Publish(connected)
-	}()
-	return nil
-}
-
-func (state *endpointWriter) sendEnvelopes(msg []interface{}, ctx actor.Context) {
-
-	envelopes := make([]*MessageEnvelope, len(msg))
-
-	//type name uniqueness map name string to type index
-	typeNames := make(map[string]int32)
-	typeNamesArr := make([]string, 0)
-	targetNames := make(map[string]int32)
-	targetNamesArr := make([]string, 0)
-	var header *MessageHeader
-	var typeID int32
-	var targetID int32
-	var serializerID int32
-
-	for i, tmp := range msg {
-
-		rd := tmp.(*remoteDeliver)
-
-		if rd.serializerID == -1 {
-			serializerID = state.defaultSerializerId
-		} else {
-			serializerID = rd.serializerID
-		}
-
-		if rd.header == nil || rd.header.Length() == 0 {
-			header = nil
-		} else {
-			header = &MessageHeader{rd.header.ToMap()}
-		}
-
-		bytes, typeName, err := Serialize(rd.message, serializerID)
-		if err != nil {
-			panic(err)
-		}
-		typeID, typeNamesArr = addToLookup(typeNames, typeName, typeNamesArr)
-		targetID, targetNamesArr = addToLookup(targetNames, rd.target.Id, targetNamesArr)
-
-		envelopes[i] = &MessageEnvelope{
-			MessageHeader: header,
-			MessageData:   bytes,
-			Sender:        rd.sender,
-			Target:        targetID,
-			TypeId:        typeID,
-			SerializerId:  serializerID,
-		}
-	}
-
-	batch := &MessageBatch{
-		TypeNames:   typeNamesArr,
-		TargetNames: targetNamesArr,
-		Envelopes:   envelopes,
-	}
-
-	batchstr, _, _ := Serialize(batch, serializerID)
-
-	_, err := state.conn.Send(string(batchstr), 0)
-
-	if err != nil {
-		ctx.Stash()
-		panic("restart it")
-	}
-}
-
-func addToLookup(m map[string]int32, name string, a []string) (int32, []string) {
-	max := int32(len(m))
-	id, ok := m[name]
-	if !ok {
-		m[name] = max
-		id = max
-		a = append(a, name)
-	}
-	return id, a
-}
-
-func (state *endpointWriter) Receive(ctx actor.Context) {
-	switch msg := ctx.Message().(type) {
-	case *actor.Started:
-		state.initialize()
-	case *actor.Stopped:
-		state.conn.Close()
-	case *actor.Restarting:
-		state.conn.Close()
-	case []interface{}:
-		state.sendEnvelopes(msg, ctx)
-	case actor.SystemMessage, actor.AutoReceiveMessage:
-	default:
-		plog.Error("EndpointWriter received unknown message", log.String("address", state.address), log.TypeOf("type", msg), log.Message(msg))
-	}
-}
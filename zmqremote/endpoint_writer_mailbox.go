@@ -1,161 +0,0 @@
-/****************************************************
-Copyright 2019 The OnyxChain-eventbus Authors
-
-Licensed under the Apache License, Version 2.0 (the "License");
-you may not use this file except in compliance with the License.
-You may obtain a copy of the License at
-
-    http://www.apache.org/licenses/LICENSE-2.0
-
-Unless required by applicable law or agreed to in writing, software
-distributed under the License is distributed on an "AS IS" BASIS,
-WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-See the License for the specific language governing permissions and
-limitations under the License.
-*****************************************************/
-
-/***************************************************
-Copyright 2016 https://github.com/AsynkronIT/protoactor-go
-
-Licensed under the Apache License, Version 2.0 (the "License");
-you may not use this file except in compliance with the License.
-You may obtain a copy of the License at
-
-    http://www.apache.org/licenses/LICENSE-2.0
-
-Unless required by applicable law or agreed to in writing, software
-distributed under the License is distributed on an "AS IS" BASIS,
-WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-See the License for the specific language governing permissions and
-limitations under the License.
-*****************************************************/
-package zmqremote
-
-import (
-	"runtime"
-	"sync/atomic"
-
-	"fmt"
-	"github.com/OnyxPay/OnyxChain-eventbus/internal/queue/goring"
-	"github.com/OnyxPay/OnyxChain-eventbus/internal/queue/mpsc"
-	"github.com/OnyxPay/OnyxChain-eventbus/log"
-	"github.com/OnyxPay/OnyxChain-eventbus/mailbox"
-)
-
-const (
-	mailboxIdle    int32 = iota
-	mailboxRunning int32 = iota
-)
-const (
-	mailboxHasNoMessages   int32 = iota
-	mailboxHasMoreMessages int32 = iota
-)
-
-type endpointWriterMailbox struct {
-	userMailbox     *goring.Queue
-	systemMailbox   *mpsc.Queue
-	schedulerStatus int32
-	hasMoreMessages int32
-	invoker         mailbox.MessageInvoker
-	batchSize       int
-	dispatcher      mailbox.Dispatcher
-	suspended       bool
-}
-
-func (m *endpointWriterMailbox) PostUserMessage(message interface{}) {
-	//batching mailbox only use the message part
-	m.userMailbox.Push(message)
-	m.schedule()
-}
-
-func (m *endpointWriterMailbox) PostSystemMessage(message interface{}) {
-	m.systemMailbox.Push(message)
-	m.schedule()
-}
-
-func (m *endpointWriterMailbox) schedule() {
-	atomic.StoreInt32(&m.hasMoreMessages, mailboxHasMoreMessages) //we have more messages to process
-	if atomic.CompareAndSwapInt32(&m.schedulerStatus, mailboxIdle, mailboxRunning) {
-		m.dispatcher.Schedule(m.processMessages)
-	}
-}
-
-func (m *endpointWriterMailbox) processMessages() {
-	//we are about to start processing messages, we can safely reset the message flag of the mailbox
-	atomic.StoreInt32(&m.hasMoreMessages, mailboxHasNoMessages)
-process:
-	m.run()
-
-	// set mailbox to idle
-	atomic.StoreInt32(&m.schedulerStatus, mailboxIdle)
-
-	// check if there are still messages to process (sent after the message loop ended)
-	if atomic.SwapInt32(&m.hasMoreMessages, mailboxHasNoMessages) == mailboxHasMoreMessages {
-		// try setting the mailbox back to running
-		if atomic.CompareAndSwapInt32(&m.schedulerStatus, mailboxIdle, mailboxRunning) {
-			goto process
-		}
-	}
-}
-
-func (m *endpointWriterMailbox) run() {
-	var msg interface{}
-	defer func() {
-		if r := recover(); r != nil {
-			var buf [1024]byte
-			runtime.Stack(buf[:], true)
-			fmt.Println(string(buf[:]))
-			plog.Debug("[ACTOR] Recovering", log.Object("actor", m.invoker), log.Object("reason", r), log.Stack(), log.Object("stack", string(buf[:])))
-			m.invoker.EscalateFailure(r, msg)
-		}
-	}()
-
-	for {
-		// keep processing system messages until queue is empty
-		if msg = m.systemMailbox.Pop(); msg != nil {
-			switch msg.(type) {
-			case *mailbox.SuspendMailbox:
-				m.suspended = true
-			case *mailbox.ResumeMailbox:
-				m.suspended = false
-			default:
-				m.invoker.InvokeSystemMessage(msg)
-			}
-
-			continue
-		}
-
-		// didn't process a system message, so break until we are resumed
-		if m.suspended {
-			return
-		}
-
-		var ok bool
-		if msg, ok = m.userMailbox.PopMany(int64(m.batchSize)); ok {
-			m.invoker.InvokeUserMessage(msg)
-		} else {
-			return
-		}
-
-		runtime.Gosched()
-	}
-}
-
-func newEndpointWriterMailbox(batchSize, initialSize int) mailbox.Producer {
-	return func(invoker mailbox.MessageInvoker, dispatcher mailbox.Dispatcher) mailbox.Inbound {
-		userMailbox := goring.New(int64(initialSize))
-		systemMailbox := mpsc.New()
-		return &endpointWriterMailbox{
-			userMailbox:     userMailbox,
-			systemMailbox:   systemMailbox,
-			hasMoreMessages: mailboxHasNoMessages,
-			schedulerStatus: mailboxIdle,
-			batchSize:       batchSize,
-			invoker:         invoker,
-			dispatcher:      dispatcher,
-		}
-	}
-}
-
-func (m *endpointWriterMailbox) Start() {
-}
@@ -1,68 +1,20 @@
-/****************************************************
-Copyright 2019 The OnyxChain-eventbus Authors
-
-Licensed under the Apache License, Version 2.0 (the "License");
-you may not use this file except in compliance with the License.
-You may obtain a copy of the License at
-
-    http://www.apache.org/licenses/LICENSE-2.0
-
-Unless required by applicable law or agreed to in writing, software
-distributed under the License is distributed on an "AS IS" BASIS,
-WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-See the License for the specific language governing permissions and
-limitations under the License.
-*****************************************************/
-
-/***************************************************
-Copyright 2016 https://github.com/AsynkronIT/protoactor-go
-
-Licensed under the Apache License, Version 2.0 (the "License");
-you may not use this file except in compliance with the License.
-You may obtain a copy of the License at
-
-    http://www.apache.org/licenses/LICENSE-2.0
-
-Unless required by applicable law or agreed to in writing, software
-distributed under the License is distributed on an "AS IS" BASIS,
-WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-See the License for the specific language governing permissions and
-limitations under the License.
-*****************************************************/
 // Code generated by protoc-gen-gogo. DO NOT EDIT.
 // source: github.com/OnyxPay/OnyxChain-eventbus/zmqremote/protos.proto
 
-/*
-	Package zmqremote is a generated protocol buffer package.
-
-	It is generated from these files:
-		github.com/OnyxPay/OnyxChain-eventbus/zmqremote/protos.proto
-
-	It has these top-level messages:
-		MessageBatch
-		MessageEnvelope
-		MessageHeader
-		ActorPidRequest
-		ActorPidResponse
-		MsgData
-*/
 package zmqremote
 
-import proto "github.com/gogo/protobuf/proto"
-import fmt "fmt"
-import math "math"
-import _ "github.com/gogo/protobuf/gogoproto"
-import actor "github.com/OnyxPay/OnyxChain-eventbus/actor"
-
-import strconv "strconv"
-
-import bytes "bytes"
-
-import strings "strings"
-import reflect "reflect"
-import sortkeys "github.com/gogo/protobuf/sortkeys"
-
-import io "io"
+import (
+	bytes "bytes"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	reflect "reflect"
+	strconv "strconv"
+	strings "strings"
+)
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -73,7 +25,7 @@ var _ = math.Inf
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type MsgType int32
 
@@ -92,1580 +44,226 @@ var MsgType_name = map[int32]string{
 	2: "HEADER_MSG_TYPE",
 	3: "TX_MSG_TYPE",
 	4: "TX_ATT_MSG_TYPE",
-	5: "VM_CODE_MSG_TYPE",
-}
-var MsgType_value = map[string]int32{
-	"ADDRESS_MSG_TYPE": 0,
-	"BLOCK_MSG_TYPE":   1,
-	"HEADER_MSG_TYPE":  2,
-	"TX_MSG_TYPE":      3,
-	"TX_ATT_MSG_TYPE":  4,
-	"VM_CODE_MSG_TYPE": 5,
-}
-
-func (MsgType) EnumDescriptor() ([]byte, []int) { return fileDescriptorProtos, []int{0} }
-
-type MessageBatch struct {
-	TypeNames   []string           `protobuf:"bytes,1,rep,name=type_names,json=typeNames" json:"type_names,omitempty"`
-	TargetNames []string           `protobuf:"bytes,2,rep,name=target_names,json=targetNames" json:"target_names,omitempty"`
-	Envelopes   []*MessageEnvelope `protobuf:"bytes,3,rep,name=envelopes" json:"envelopes,omitempty"`
-}
-
-func (m *MessageBatch) Reset()                    { *m = MessageBatch{} }
-func (*MessageBatch) ProtoMessage()               {}
-func (*MessageBatch) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{0} }
-
-func (m *MessageBatch) GetTypeNames() []string {
-	if m != nil {
-		return m.TypeNames
-	}
-	return nil
-}
-
-func (m *MessageBatch) GetTargetNames() []string {
-	if m != nil {
-		return m.TargetNames
-	}
-	return nil
-}
-
-func (m *MessageBatch) GetEnvelopes() []*MessageEnvelope {
-	if m != nil {
-		return m.Envelopes
-	}
-	return nil
-}
-
-type MessageEnvelope struct {
-	TypeId        int32          `protobuf:"varint,1,opt,name=type_id,json=typeId,proto3" json:"type_id,omitempty"`
-	MessageData   []byte         `protobuf:"bytes,2,opt,name=message_data,json=messageData,proto3" json:"message_data,omitempty"`
-	Target        int32          `protobuf:"varint,3,opt,name=target,proto3" json:"target,omitempty"`
-	Sender        *actor.PID     `protobuf:"bytes,4,opt,name=sender" json:"sender,omitempty"`
-	SerializerId  int32          `protobuf:"varint,5,opt,name=serializer_id,json=serializerId,proto3" json:"serializer_id,omitempty"`
-	MessageHeader *MessageHeader `protobuf:"bytes,6,opt,name=message_header,json=messageHeader" json:"message_header,omitempty"`
-}
-
-func (m *MessageEnvelope) Reset()                    { *m = MessageEnvelope{} }
-func (*MessageEnvelope) ProtoMessage()               {}
-func (*MessageEnvelope) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{1} }
-
-func (m *MessageEnvelope) GetTypeId() int32 {
-	if m != nil {
-		return m.TypeId
-	}
-	return 0
-}
-
-func (m *MessageEnvelope) GetMessageData() []byte {
-	if m != nil {
-		return m.MessageData
-	}
-	return nil
-}
-
-func (m *MessageEnvelope) GetTarget() int32 {
-	if m != nil {
-		return m.Target
-	}
-	return 0
-}
-
-func (m *MessageEnvelope) GetSender() *actor.PID {
-	if m != nil {
-		return m.Sender
-	}
-	return nil
-}
-
-func (m *MessageEnvelope) GetSerializerId() int32 {
-	if m != nil {
-		return m.SerializerId
-	}
-	return 0
-}
-
-func (m *MessageEnvelope) GetMessageHeader() *MessageHeader {
-	if m != nil {
-		return m.MessageHeader
-	}
-	return nil
-}
-
-type MessageHeader struct {
-	HeaderData map[string]string `protobuf:"bytes,1,rep,name=header_data,json=headerData" json:"header_data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-}
-
-func (m *MessageHeader) Reset()                    { *m = MessageHeader{} }
-func (*MessageHeader) ProtoMessage()               {}
-func (*MessageHeader) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{2} }
-
-func (m *MessageHeader) GetHeaderData() map[string]string {
-	if m != nil {
-		return m.HeaderData
-	}
-	return nil
-}
-
-type ActorPidRequest struct {
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
-}
-
-func (m *ActorPidRequest) Reset()                    { *m = ActorPidRequest{} }
-func (*ActorPidRequest) ProtoMessage()               {}
-func (*ActorPidRequest) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{3} }
-
-func (m *ActorPidRequest) GetName() string {
-	if m != nil {
-		return m.Name
-	}
-	return ""
-}
-
-func (m *ActorPidRequest) GetKind() string {
-	if m != nil {
-		return m.Kind
-	}
-	return ""
-}
-
-type ActorPidResponse struct {
-	Pid        *actor.PID `protobuf:"bytes,1,opt,name=pid" json:"pid,omitempty"`
-	StatusCode int32      `protobuf:"varint,2,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
-}
-
-func (m *ActorPidResponse) Reset()                    { *m = ActorPidResponse{} }
-func (*ActorPidResponse) ProtoMessage()               {}
-func (*ActorPidResponse) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{4} }
-
-func (m *ActorPidResponse) GetPid() *actor.PID {
-	if m != nil {
-		return m.Pid
-	}
-	return nil
-}
-
-func (m *ActorPidResponse) GetStatusCode() int32 {
-	if m != nil {
-		return m.StatusCode
-	}
-	return 0
-}
-
-type MsgData struct {
-	MsgType MsgType `protobuf:"varint,1,opt,name=msgType,proto3,enum=zmqremote.MsgType" json:"msgType,omitempty"`
-	Data    []byte  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-}
-
-func (m *MsgData) Reset()                    { *m = MsgData{} }
-func (*MsgData) ProtoMessage()               {}
-func (*MsgData) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{5} }
-
-func (m *MsgData) GetMsgType() MsgType {
-	if m != nil {
-		return m.MsgType
-	}
-	return ADDRESS_MSG_TYPE
-}
-
-func (m *MsgData) GetData() []byte {
-	if m != nil {
-		return m.Data
-	}
-	return nil
-}
-
-func init() {
-	proto.RegisterType((*MessageBatch)(nil), "zmqremote.MessageBatch")
-	proto.RegisterType((*MessageEnvelope)(nil), "zmqremote.MessageEnvelope")
-	proto.RegisterType((*MessageHeader)(nil), "zmqremote.MessageHeader")
-	proto.RegisterType((*ActorPidRequest)(nil), "zmqremote.ActorPidRequest")
-	proto.RegisterType((*ActorPidResponse)(nil), "zmqremote.ActorPidResponse")
-	proto.RegisterType((*MsgData)(nil), "zmqremote.MsgData")
-	proto.RegisterEnum("zmqremote.MsgType", MsgType_name, MsgType_value)
-}
-func (x MsgType) String() string {
-	s, ok := MsgType_name[int32(x)]
-	if ok {
-		return s
-	}
-	return strconv.Itoa(int(x))
-}
-func (this *MessageBatch) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
-
-	that1, ok := that.(*MessageBatch)
-	if !ok {
-		that2, ok := that.(MessageBatch)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.TypeNames) != len(that1.TypeNames) {
-		return false
-	}
-	for i := range this.TypeNames {
-		if this.TypeNames[i] != that1.TypeNames[i] {
-			return false
-		}
-	}
-	if len(this.TargetNames) != len(that1.TargetNames) {
-		return false
-	}
-	for i := range this.TargetNames {
-		if this.TargetNames[i] != that1.TargetNames[i] {
-			return false
-		}
-	}
-	if len(this.Envelopes) != len(that1.Envelopes) {
-		return false
-	}
-	for i := range this.Envelopes {
-		if !this.Envelopes[i].Equal(that1.Envelopes[i]) {
-			return false
-		}
-	}
-	return true
-}
-func (this *MessageEnvelope) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
-
-	that1, ok := that.(*MessageEnvelope)
-	if !ok {
-		that2, ok := that.(MessageEnvelope)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.TypeId != that1.TypeId {
-		return false
-	}
-	if !bytes.Equal(this.MessageData, that1.MessageData) {
-		return false
-	}
-	if this.Target != that1.Target {
-		return false
-	}
-	if !this.Sender.Equal(that1.Sender) {
-		return false
-	}
-	if this.SerializerId != that1.SerializerId {
-		return false
-	}
-	if !this.MessageHeader.Equal(that1.MessageHeader) {
-		return false
-	}
-	return true
-}
-func (this *MessageHeader) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
-
-	that1, ok := that.(*MessageHeader)
-	if !ok {
-		that2, ok := that.(MessageHeader)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.HeaderData) != len(that1.HeaderData) {
-		return false
-	}
-	for i := range this.HeaderData {
-		if this.HeaderData[i] != that1.HeaderData[i] {
-			return false
-		}
-	}
-	return true
-}
-func (this *ActorPidRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
-
-	that1, ok := that.(*ActorPidRequest)
-	if !ok {
-		that2, ok := that.(ActorPidRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Name != that1.Name {
-		return false
-	}
-	if this.Kind != that1.Kind {
-		return false
-	}
-	return true
-}
-func (this *ActorPidResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
-
-	that1, ok := that.(*ActorPidResponse)
-	if !ok {
-		that2, ok := that.(ActorPidResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Pid.Equal(that1.Pid) {
-		return false
-	}
-	if this.StatusCode != that1.StatusCode {
-		return false
-	}
-	return true
-}
-func (this *MsgData) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
-
-	that1, ok := that.(*MsgData)
-	if !ok {
-		that2, ok := that.(MsgData)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.MsgType != that1.MsgType {
-		return false
-	}
-	if !bytes.Equal(this.Data, that1.Data) {
-		return false
-	}
-	return true
-}
-func (m *MessageBatch) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *MessageBatch) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.TypeNames) > 0 {
-		for _, s := range m.TypeNames {
-			dAtA[i] = 0xa
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
-	}
-	if len(m.TargetNames) > 0 {
-		for _, s := range m.TargetNames {
-			dAtA[i] = 0x12
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
-	}
-	if len(m.Envelopes) > 0 {
-		for _, msg := range m.Envelopes {
-			dAtA[i] = 0x1a
-			i++
-			i = encodeVarintProtos(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
-	return i, nil
-}
-
-func (m *MessageEnvelope) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *MessageEnvelope) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.TypeId != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintProtos(dAtA, i, uint64(m.TypeId))
-	}
-	if len(m.MessageData) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintProtos(dAtA, i, uint64(len(m.MessageData)))
-		i += copy(dAtA[i:], m.MessageData)
-	}
-	if m.Target != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintProtos(dAtA, i, uint64(m.Target))
-	}
-	if m.Sender != nil {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintProtos(dAtA, i, uint64(m.Sender.Size()))
-		n1, err := m.Sender.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n1
-	}
-	if m.SerializerId != 0 {
-		dAtA[i] = 0x28
-		i++
-		i = encodeVarintProtos(dAtA, i, uint64(m.SerializerId))
-	}
-	if m.MessageHeader != nil {
-		dAtA[i] = 0x32
-		i++
-		i = encodeVarintProtos(dAtA, i, uint64(m.MessageHeader.Size()))
-		n2, err := m.MessageHeader.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n2
-	}
-	return i, nil
-}
-
-func (m *MessageHeader) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *MessageHeader) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.HeaderData) > 0 {
-		for k, _ := range m.HeaderData {
-			dAtA[i] = 0xa
-			i++
-			v := m.HeaderData[k]
-			mapSize := 1 + len(k) + sovProtos(uint64(len(k))) + 1 + len(v) + sovProtos(uint64(len(v)))
-			i = encodeVarintProtos(dAtA, i, uint64(mapSize))
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintProtos(dAtA, i, uint64(len(k)))
-			i += copy(dAtA[i:], k)
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintProtos(dAtA, i, uint64(len(v)))
-			i += copy(dAtA[i:], v)
-		}
-	}
-	return i, nil
-}
-
-func (m *ActorPidRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *ActorPidRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Name) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintProtos(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
-	}
-	if len(m.Kind) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintProtos(dAtA, i, uint64(len(m.Kind)))
-		i += copy(dAtA[i:], m.Kind)
-	}
-	return i, nil
-}
-
-func (m *ActorPidResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *ActorPidResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Pid != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintProtos(dAtA, i, uint64(m.Pid.Size()))
-		n3, err := m.Pid.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n3
-	}
-	if m.StatusCode != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintProtos(dAtA, i, uint64(m.StatusCode))
-	}
-	return i, nil
-}
-
-func (m *MsgData) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *MsgData) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.MsgType != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintProtos(dAtA, i, uint64(m.MsgType))
-	}
-	if len(m.Data) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintProtos(dAtA, i, uint64(len(m.Data)))
-		i += copy(dAtA[i:], m.Data)
-	}
-	return i, nil
-}
-
-func encodeVarintProtos(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
-}
-func (m *MessageBatch) Size() (n int) {
-	var l int
-	_ = l
-	if len(m.TypeNames) > 0 {
-		for _, s := range m.TypeNames {
-			l = len(s)
-			n += 1 + l + sovProtos(uint64(l))
-		}
-	}
-	if len(m.TargetNames) > 0 {
-		for _, s := range m.TargetNames {
-			l = len(s)
-			n += 1 + l + sovProtos(uint64(l))
-		}
-	}
-	if len(m.Envelopes) > 0 {
-		for _, e := range m.Envelopes {
-			l = e.Size()
-			n += 1 + l + sovProtos(uint64(l))
-		}
-	}
-	return n
-}
-
-func (m *MessageEnvelope) Size() (n int) {
-	var l int
-	_ = l
-	if m.TypeId != 0 {
-		n += 1 + sovProtos(uint64(m.TypeId))
-	}
-	l = len(m.MessageData)
-	if l > 0 {
-		n += 1 + l + sovProtos(uint64(l))
-	}
-	if m.Target != 0 {
-		n += 1 + sovProtos(uint64(m.Target))
-	}
-	if m.Sender != nil {
-		l = m.Sender.Size()
-		n += 1 + l + sovProtos(uint64(l))
-	}
-	if m.SerializerId != 0 {
-		n += 1 + sovProtos(uint64(m.SerializerId))
-	}
-	if m.MessageHeader != nil {
-		l = m.MessageHeader.Size()
-		n += 1 + l + sovProtos(uint64(l))
-	}
-	return n
-}
-
-func (m *MessageHeader) Size() (n int) {
-	var l int
-	_ = l
-	if len(m.HeaderData) > 0 {
-		for k, v := range m.HeaderData {
-			_ = k
-			_ = v
-			mapEntrySize := 1 + len(k) + sovProtos(uint64(len(k))) + 1 + len(v) + sovProtos(uint64(len(v)))
-			n += mapEntrySize + 1 + sovProtos(uint64(mapEntrySize))
-		}
-	}
-	return n
-}
-
-func (m *ActorPidRequest) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovProtos(uint64(l))
-	}
-	l = len(m.Kind)
-	if l > 0 {
-		n += 1 + l + sovProtos(uint64(l))
-	}
-	return n
-}
-
-func (m *ActorPidResponse) Size() (n int) {
-	var l int
-	_ = l
-	if m.Pid != nil {
-		l = m.Pid.Size()
-		n += 1 + l + sovProtos(uint64(l))
-	}
-	if m.StatusCode != 0 {
-		n += 1 + sovProtos(uint64(m.StatusCode))
-	}
-	return n
-}
-
-func (m *MsgData) Size() (n int) {
-	var l int
-	_ = l
-	if m.MsgType != 0 {
-		n += 1 + sovProtos(uint64(m.MsgType))
-	}
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovProtos(uint64(l))
-	}
-	return n
-}
-
-func sovProtos(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
-	}
-	return n
-}
-func sozProtos(x uint64) (n int) {
-	return sovProtos(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (this *MessageBatch) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&MessageBatch{`,
-		`TypeNames:` + fmt.Sprintf("%v", this.TypeNames) + `,`,
-		`TargetNames:` + fmt.Sprintf("%v", this.TargetNames) + `,`,
-		`Envelopes:` + strings.Replace(fmt.Sprintf("%v", this.Envelopes), "MessageEnvelope", "MessageEnvelope", 1) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *MessageEnvelope) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&MessageEnvelope{`,
-		`TypeId:` + fmt.Sprintf("%v", this.TypeId) + `,`,
-		`MessageData:` + fmt.Sprintf("%v", this.MessageData) + `,`,
-		`Target:` + fmt.Sprintf("%v", this.Target) + `,`,
-		`Sender:` + strings.Replace(fmt.Sprintf("%v", this.Sender), "PID", "actor.PID", 1) + `,`,
-		`SerializerId:` + fmt.Sprintf("%v", this.SerializerId) + `,`,
-		`MessageHeader:` + strings.Replace(fmt.Sprintf("%v", this.MessageHeader), "MessageHeader", "MessageHeader", 1) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *MessageHeader) String() string {
-	if this == nil {
-		return "nil"
-	}
-	keysForHeaderData := make([]string, 0, len(this.HeaderData))
-	for k, _ := range this.HeaderData {
-		keysForHeaderData = append(keysForHeaderData, k)
-	}
-	sortkeys.Strings(keysForHeaderData)
-	mapStringForHeaderData := "map[string]string{"
-	for _, k := range keysForHeaderData {
-		mapStringForHeaderData += fmt.Sprintf("%v: %v,", k, this.HeaderData[k])
-	}
-	mapStringForHeaderData += "}"
-	s := strings.Join([]string{`&MessageHeader{`,
-		`HeaderData:` + mapStringForHeaderData + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ActorPidRequest) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&ActorPidRequest{`,
-		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
-		`Kind:` + fmt.Sprintf("%v", this.Kind) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ActorPidResponse) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&ActorPidResponse{`,
-		`Pid:` + strings.Replace(fmt.Sprintf("%v", this.Pid), "PID", "actor.PID", 1) + `,`,
-		`StatusCode:` + fmt.Sprintf("%v", this.StatusCode) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *MsgData) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&MsgData{`,
-		`MsgType:` + fmt.Sprintf("%v", this.MsgType) + `,`,
-		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func valueToStringProtos(v interface{}) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
-	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
-}
-func (m *MessageBatch) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowProtos
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: MessageBatch: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MessageBatch: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TypeNames", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtos
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthProtos
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.TypeNames = append(m.TypeNames, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TargetNames", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtos
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthProtos
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.TargetNames = append(m.TargetNames, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Envelopes", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtos
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthProtos
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Envelopes = append(m.Envelopes, &MessageEnvelope{})
-			if err := m.Envelopes[len(m.Envelopes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipProtos(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthProtos
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *MessageEnvelope) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowProtos
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: MessageEnvelope: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MessageEnvelope: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TypeId", wireType)
-			}
-			m.TypeId = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtos
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.TypeId |= (int32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MessageData", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtos
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthProtos
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.MessageData = append(m.MessageData[:0], dAtA[iNdEx:postIndex]...)
-			if m.MessageData == nil {
-				m.MessageData = []byte{}
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
-			}
-			m.Target = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtos
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Target |= (int32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtos
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthProtos
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Sender == nil {
-				m.Sender = &actor.PID{}
-			}
-			if err := m.Sender.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SerializerId", wireType)
-			}
-			m.SerializerId = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtos
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.SerializerId |= (int32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MessageHeader", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtos
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthProtos
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.MessageHeader == nil {
-				m.MessageHeader = &MessageHeader{}
-			}
-			if err := m.MessageHeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipProtos(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthProtos
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *MessageHeader) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowProtos
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: MessageHeader: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MessageHeader: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HeaderData", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtos
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthProtos
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.HeaderData == nil {
-				m.HeaderData = make(map[string]string)
-			}
-			var mapkey string
-			var mapvalue string
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowProtos
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowProtos
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthProtos
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var stringLenmapvalue uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowProtos
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapvalue := int(stringLenmapvalue)
-					if intStringLenmapvalue < 0 {
-						return ErrInvalidLengthProtos
-					}
-					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
-					if postStringIndexmapvalue > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
-					iNdEx = postStringIndexmapvalue
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipProtos(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthProtos
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
-			}
-			m.HeaderData[mapkey] = mapvalue
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipProtos(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthProtos
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ActorPidRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowProtos
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ActorPidRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ActorPidRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtos
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthProtos
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Name = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Kind", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtos
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthProtos
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Kind = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipProtos(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthProtos
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
+	5: "VM_CODE_MSG_TYPE",
+}
+
+var MsgType_value = map[string]int32{
+	"ADDRESS_MSG_TYPE": 0,
+	"BLOCK_MSG_TYPE":   1,
+	"HEADER_MSG_TYPE":  2,
+	"TX_MSG_TYPE":      3,
+	"TX_ATT_MSG_TYPE":  4,
+	"VM_CODE_MSG_TYPE": 5,
+}
+
+func (MsgType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e6607f3ab9bcf2ac, []int{0}
+}
+
+type MsgData struct {
+	MsgType MsgType `protobuf:"varint,1,opt,name=msgType,proto3,enum=zmqremote.MsgType" json:"msgType,omitempty"`
+	Data    []byte  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *MsgData) Reset()      { *m = MsgData{} }
+func (*MsgData) ProtoMessage() {}
+func (*MsgData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6607f3ab9bcf2ac, []int{0}
+}
+func (m *MsgData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MsgData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MsgData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
+}
+func (m *MsgData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MsgData.Merge(m, src)
+}
+func (m *MsgData) XXX_Size() int {
+	return m.Size()
+}
+func (m *MsgData) XXX_DiscardUnknown() {
+	xxx_messageInfo_MsgData.DiscardUnknown(m)
+}
 
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
+var xxx_messageInfo_MsgData proto.InternalMessageInfo
+
+func (m *MsgData) GetMsgType() MsgType {
+	if m != nil {
+		return m.MsgType
+	}
+	return ADDRESS_MSG_TYPE
+}
+
+func (m *MsgData) GetData() []byte {
+	if m != nil {
+		return m.Data
 	}
 	return nil
 }
-func (m *ActorPidResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowProtos
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ActorPidResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ActorPidResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Pid", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtos
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthProtos
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Pid == nil {
-				m.Pid = &actor.PID{}
-			}
-			if err := m.Pid.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StatusCode", wireType)
-			}
-			m.StatusCode = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtos
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.StatusCode |= (int32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipProtos(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthProtos
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
+
+func init() {
+	proto.RegisterEnum("zmqremote.MsgType", MsgType_name, MsgType_value)
+	proto.RegisterType((*MsgData)(nil), "zmqremote.MsgData")
+}
+
+func init() {
+	proto.RegisterFile("github.com/OnyxPay/OnyxChain-eventbus/zmqremote/protos.proto", fileDescriptor_e6607f3ab9bcf2ac)
+}
+
+var fileDescriptor_e6607f3ab9bcf2ac = []byte{
+	// 309 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x90, 0x31, 0x4f, 0xc2, 0x40,
+	0x18, 0x86, 0xef, 0x10, 0x25, 0x9e, 0x06, 0xc8, 0xe9, 0x40, 0x1c, 0xbe, 0x10, 0x27, 0x62, 0xa4,
+	0x24, 0xea, 0xe8, 0x02, 0xb4, 0xd1, 0x04, 0x1b, 0x48, 0xb9, 0x18, 0x9c, 0x9a, 0xab, 0xd6, 0xc2,
+	0x50, 0x0e, 0xe9, 0x61, 0xc4, 0xc9, 0xc1, 0x1f, 0xe0, 0xcf, 0xf0, 0xa7, 0x38, 0x32, 0x32, 0xda,
+	0xeb, 0xe2, 0xc8, 0x4f, 0x30, 0x5c, 0x23, 0x75, 0xfa, 0xbe, 0xf7, 0x79, 0xdf, 0x7b, 0x2f, 0xf9,
+	0xc8, 0x65, 0x30, 0x92, 0xc3, 0x99, 0x67, 0xdc, 0x8b, 0xb0, 0xd1, 0x1d, 0xcf, 0x5f, 0x7a, 0x7c,
+	0xae, 0x67, 0x7b, 0xc8, 0x47, 0xe3, 0xba, 0xff, 0xec, 0x8f, 0xa5, 0x37, 0x8b, 0x1a, 0xaf, 0xe1,
+	0xd3, 0xd4, 0x0f, 0x85, 0xf4, 0x1b, 0x93, 0xa9, 0x90, 0x22, 0x32, 0xf4, 0xa0, 0xbb, 0x1b, 0x7e,
+	0x54, 0xff, 0x57, 0x14, 0x88, 0x40, 0xa4, 0x41, 0x6f, 0xf6, 0xa8, 0x95, 0x16, 0x7a, 0x4b, 0x5f,
+	0x1e, 0x77, 0x48, 0xc1, 0x8e, 0x02, 0x93, 0x4b, 0x4e, 0x4f, 0x49, 0x21, 0x8c, 0x02, 0x36, 0x9f,
+	0xf8, 0x15, 0x5c, 0xc5, 0xb5, 0xe2, 0x19, 0x35, 0x36, 0xb5, 0x86, 0x9d, 0x3a, 0xce, 0x5f, 0x84,
+	0x52, 0x92, 0x7f, 0xe0, 0x92, 0x57, 0x72, 0x55, 0x5c, 0xdb, 0x77, 0xf4, 0x7e, 0xf2, 0x8e, 0x75,
+	0x9b, 0xf6, 0x0f, 0x49, 0xb9, 0x69, 0x9a, 0x8e, 0xd5, 0xef, 0xbb, 0x76, 0xff, 0xca, 0x65, 0x77,
+	0x3d, 0xab, 0x8c, 0x28, 0x25, 0xc5, 0xd6, 0x4d, 0xb7, 0xdd, 0xc9, 0x18, 0xa6, 0x07, 0xa4, 0x74,
+	0x6d, 0x35, 0x4d, 0xcb, 0xc9, 0x60, 0x8e, 0x96, 0xc8, 0x1e, 0x1b, 0x64, 0x60, 0x6b, 0x9d, 0x62,
+	0x03, 0xb7, 0xc9, 0x58, 0x06, 0xf3, 0xeb, 0x4f, 0x6e, 0x6d, 0xb7, 0xdd, 0x35, 0xad, 0x8c, 0x6e,
+	0xb7, 0x2e, 0x16, 0x31, 0xa0, 0x65, 0x0c, 0x68, 0x15, 0x03, 0x7a, 0x53, 0x80, 0x3f, 0x15, 0xe0,
+	0x2f, 0x05, 0x78, 0xa1, 0x00, 0x7f, 0x2b, 0xc0, 0x3f, 0x0a, 0xd0, 0x4a, 0x01, 0xfe, 0x48, 0x00,
+	0x2d, 0x12, 0x40, 0xcb, 0x04, 0x90, 0xb7, 0xa3, 0x0f, 0x72, 0xfe, 0x3b, 0x00, 0x50, 0x02, 0xbd,
+	0xba, 0x8a, 0x01, 0x00, 0x00,
+}
+
+func (x MsgType) String() string {
+	s, ok := MsgType_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (this *MsgData) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*MsgData)
+	if !ok {
+		that2, ok := that.(MsgData)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.MsgType != that1.MsgType {
+		return false
+	}
+	if !bytes.Equal(this.Data, that1.Data) {
+		return false
+	}
+	return true
+}
+func (m *MsgData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
 
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
+func (m *MsgData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MsgData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x12
 	}
-	return nil
+	if m.MsgType != 0 {
+		i = encodeVarintProtos(dAtA, i, uint64(m.MsgType))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintProtos(dAtA []byte, offset int, v uint64) int {
+	offset -= sovProtos(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *MsgData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MsgType != 0 {
+		n += 1 + sovProtos(uint64(m.MsgType))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	return n
+}
+
+func sovProtos(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozProtos(x uint64) (n int) {
+	return sovProtos(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *MsgData) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&MsgData{`,
+		`MsgType:` + fmt.Sprintf("%v", this.MsgType) + `,`,
+		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringProtos(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
 }
 func (m *MsgData) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
@@ -1682,7 +280,7 @@ func (m *MsgData) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -1710,7 +308,7 @@ func (m *MsgData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MsgType |= (MsgType(b) & 0x7F) << shift
+				m.MsgType |= MsgType(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1729,7 +327,7 @@ func (m *MsgData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1738,6 +336,9 @@ func (m *MsgData) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthProtos
 			}
 			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtos
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -1752,7 +353,7 @@ func (m *MsgData) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthProtos
 			}
 			if (iNdEx + skippy) > l {
@@ -1770,6 +371,7 @@ func (m *MsgData) Unmarshal(dAtA []byte) error {
 func skipProtos(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
+	depth := 0
 	for iNdEx < l {
 		var wire uint64
 		for shift := uint(0); ; shift += 7 {
@@ -1801,10 +403,8 @@ func skipProtos(dAtA []byte) (n int, err error) {
 					break
 				}
 			}
-			return iNdEx, nil
 		case 1:
 			iNdEx += 8
-			return iNdEx, nil
 		case 2:
 			var length int
 			for shift := uint(0); ; shift += 7 {
@@ -1821,101 +421,34 @@ func skipProtos(dAtA []byte) (n int, err error) {
 					break
 				}
 			}
-			iNdEx += length
 			if length < 0 {
 				return 0, ErrInvalidLengthProtos
 			}
-			return iNdEx, nil
+			iNdEx += length
 		case 3:
-			for {
-				var innerWire uint64
-				var start int = iNdEx
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return 0, ErrIntOverflowProtos
-					}
-					if iNdEx >= l {
-						return 0, io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					innerWire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				innerWireType := int(innerWire & 0x7)
-				if innerWireType == 4 {
-					break
-				}
-				next, err := skipProtos(dAtA[start:])
-				if err != nil {
-					return 0, err
-				}
-				iNdEx = start + next
-			}
-			return iNdEx, nil
+			depth++
 		case 4:
-			return iNdEx, nil
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupProtos
+			}
+			depth--
 		case 5:
 			iNdEx += 4
-			return iNdEx, nil
 		default:
 			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
 		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthProtos
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
 	}
-	panic("unreachable")
+	return 0, io.ErrUnexpectedEOF
 }
 
 var (
-	ErrInvalidLengthProtos = fmt.Errorf("proto: negative length found during unmarshaling")
-	ErrIntOverflowProtos   = fmt.Errorf("proto: integer overflow")
+	ErrInvalidLengthProtos        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowProtos          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupProtos = fmt.Errorf("proto: unexpected end of group")
 )
-
-func init() {
-	proto.RegisterFile("github.com/OnyxPay/OnyxChain-eventbus/zmqremote/protos.proto", fileDescriptorProtos)
-}
-
-var fileDescriptorProtos = []byte{
-	// 635 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x93, 0xbf, 0x6f, 0xd3, 0x40,
-	0x14, 0xc7, 0x73, 0x49, 0x93, 0x2a, 0x2f, 0x69, 0x13, 0x1d, 0x15, 0x44, 0x11, 0x98, 0x60, 0x96,
-	0x08, 0x15, 0x07, 0x95, 0xa5, 0x20, 0x21, 0x94, 0xd6, 0x16, 0x8d, 0x4a, 0xda, 0x72, 0xb5, 0x50,
-	0x99, 0x2c, 0x27, 0x3e, 0x9c, 0xa8, 0xb5, 0x9d, 0xfa, 0x2e, 0x95, 0xd2, 0x89, 0x81, 0x89, 0x89,
-	0x95, 0xff, 0x80, 0x3f, 0x85, 0xb1, 0x23, 0x23, 0x35, 0x0b, 0x1b, 0xfd, 0x13, 0xd0, 0xdd, 0xb9,
-	0x75, 0x5a, 0xd4, 0xc9, 0xef, 0x7d, 0xde, 0xf7, 0xfd, 0xbc, 0x04, 0x9e, 0xf9, 0x63, 0x3e, 0x9a,
-	0x0e, 0x8c, 0x61, 0x14, 0x74, 0x76, 0x77, 0xec, 0x9e, 0xd9, 0xa1, 0x27, 0x34, 0xe4, 0x83, 0x29,
-	0xeb, 0x9c, 0x06, 0xc7, 0x31, 0x0d, 0x22, 0x4e, 0x3b, 0x93, 0x38, 0xe2, 0x11, 0x33, 0xe4, 0x07,
-	0x97, 0xaf, 0x78, 0xf3, 0xe9, 0x5c, 0xb2, 0x1f, 0xf9, 0x91, 0x12, 0x0e, 0xa6, 0x1f, 0xa5, 0x27,
-	0x1d, 0x69, 0xa9, 0xcc, 0xe6, 0xea, 0xed, 0xbd, 0xdc, 0x21, 0x8f, 0xe2, 0x6b, 0x7d, 0xf4, 0x2f,
-	0x08, 0xaa, 0x7d, 0xca, 0x98, 0xeb, 0xd3, 0x0d, 0x97, 0x0f, 0x47, 0xf8, 0x01, 0x00, 0x9f, 0x4d,
-	0xa8, 0x13, 0xba, 0x01, 0x65, 0x0d, 0xd4, 0x2a, 0xb4, 0xcb, 0xa4, 0x2c, 0xc8, 0x8e, 0x00, 0xf8,
-	0x11, 0x54, 0xb9, 0x1b, 0xfb, 0x94, 0xa7, 0x82, 0xbc, 0x14, 0x54, 0x14, 0x53, 0x92, 0x75, 0x28,
-	0xd3, 0xf0, 0x84, 0x1e, 0x45, 0x13, 0xca, 0x1a, 0x85, 0x56, 0xa1, 0x5d, 0x59, 0x6b, 0x1a, 0x57,
-	0xeb, 0x18, 0x69, 0x37, 0x2b, 0x95, 0x90, 0x4c, 0xac, 0xff, 0x45, 0x50, 0xbb, 0x11, 0xc6, 0xf7,
-	0x60, 0x51, 0xce, 0x33, 0xf6, 0x1a, 0xa8, 0x85, 0xda, 0x45, 0x52, 0x12, 0x6e, 0xcf, 0x13, 0x93,
-	0x04, 0x4a, 0xeb, 0x78, 0x2e, 0x77, 0x1b, 0xf9, 0x16, 0x6a, 0x57, 0x49, 0x25, 0x65, 0xa6, 0xcb,
-	0x5d, 0x7c, 0x17, 0x4a, 0x6a, 0xb0, 0x46, 0x21, 0x4d, 0x95, 0x1e, 0xd6, 0xa1, 0xc4, 0x68, 0xe8,
-	0xd1, 0xb8, 0xb1, 0xd0, 0x42, 0xed, 0xca, 0x1a, 0x18, 0xf2, 0x32, 0xc6, 0x5e, 0xcf, 0x24, 0x69,
-	0x04, 0x3f, 0x86, 0x25, 0x46, 0xe3, 0xb1, 0x7b, 0x34, 0x3e, 0xa5, 0xb1, 0xe8, 0x5e, 0x94, 0x25,
-	0xaa, 0x19, 0xec, 0x79, 0xf8, 0x35, 0x2c, 0x5f, 0xce, 0x30, 0xa2, 0xae, 0x28, 0x58, 0x92, 0x05,
-	0x1b, 0xff, 0xef, 0xbb, 0x25, 0xe3, 0x64, 0x29, 0x98, 0x77, 0xf5, 0x6f, 0x08, 0x96, 0xae, 0x09,
-	0x70, 0x0f, 0x2a, 0xaa, 0x94, 0xda, 0x0a, 0xc9, 0xfb, 0xb5, 0x6f, 0xab, 0x67, 0xa8, 0x8f, 0xd8,
-	0xd6, 0x0a, 0x79, 0x3c, 0x23, 0x30, 0xba, 0x02, 0xcd, 0x57, 0x50, 0xbb, 0x11, 0xc6, 0x75, 0x28,
-	0x1c, 0xd2, 0x99, 0xbc, 0x64, 0x99, 0x08, 0x13, 0xaf, 0x40, 0xf1, 0xc4, 0x3d, 0x9a, 0x52, 0x79,
-	0xbf, 0x32, 0x51, 0xce, 0xcb, 0xfc, 0x3a, 0xd2, 0x5f, 0x40, 0xad, 0x2b, 0xce, 0xb2, 0x37, 0xf6,
-	0x08, 0x3d, 0x9e, 0x52, 0xc6, 0x31, 0x86, 0x05, 0xf1, 0xec, 0x69, 0xbe, 0xb4, 0x05, 0x3b, 0x1c,
-	0x87, 0x5e, 0x9a, 0x2f, 0x6d, 0xfd, 0x1d, 0xd4, 0xb3, 0x54, 0x36, 0x89, 0x42, 0x46, 0xf1, 0x7d,
-	0x28, 0x4c, 0xd2, 0x47, 0xbc, 0x7e, 0x71, 0x81, 0xf1, 0x43, 0xa8, 0x30, 0xee, 0xf2, 0x29, 0x73,
-	0x86, 0x91, 0xa7, 0x86, 0x29, 0x12, 0x50, 0x68, 0x33, 0xf2, 0xa8, 0xbe, 0x0d, 0x8b, 0x7d, 0xe6,
-	0xcb, 0x67, 0x5d, 0x85, 0xc5, 0x80, 0xf9, 0xf6, 0x6c, 0xa2, 0x06, 0x59, 0x5e, 0xc3, 0xf3, 0xe7,
-	0x51, 0x11, 0x72, 0x29, 0x11, 0xf3, 0xcd, 0xfd, 0x3e, 0xa4, 0xfd, 0xe4, 0x33, 0x92, 0xd5, 0x64,
-	0x7c, 0x05, 0xea, 0x5d, 0xd3, 0x24, 0xd6, 0xfe, 0xbe, 0xd3, 0xdf, 0x7f, 0xe3, 0xd8, 0x1f, 0xf6,
-	0xac, 0x7a, 0x0e, 0x63, 0x58, 0xde, 0x78, 0xbb, 0xbb, 0xb9, 0x9d, 0x31, 0x84, 0xef, 0x40, 0x6d,
-	0xcb, 0xea, 0x9a, 0x16, 0xc9, 0x60, 0x1e, 0xd7, 0xa0, 0x62, 0x1f, 0x64, 0xa0, 0x20, 0x54, 0xf6,
-	0x81, 0xd3, 0xb5, 0xed, 0x0c, 0x2e, 0x88, 0x26, 0xef, 0xfb, 0xce, 0xe6, 0xae, 0x69, 0x65, 0xb4,
-	0xb8, 0xb1, 0x7a, 0x76, 0xae, 0xe5, 0x7e, 0x9e, 0x6b, 0xb9, 0x8b, 0x73, 0x2d, 0xf7, 0x29, 0xd1,
-	0xd0, 0xf7, 0x44, 0x43, 0x3f, 0x12, 0x0d, 0x9d, 0x25, 0x1a, 0xfa, 0x95, 0x68, 0xe8, 0x4f, 0xa2,
-	0xe5, 0x2e, 0x12, 0x0d, 0x7d, 0xfd, 0xad, 0xe5, 0x06, 0x25, 0xf9, 0x8f, 0x7d, 0xfe, 0x2f, 0x00,
-	0x00, 0xff, 0xff, 0x44, 0xac, 0x04, 0x6f, 0x4d, 0x04, 0x00, 0x00,
-}
@@ -32,40 +32,60 @@ limitations under the License.
 package zmqremote
 
 import (
-	"github.com/OnyxPay/OnyxChain-eventbus/actor"
-	"github.com/OnyxPay/OnyxChain-eventbus/log"
-	zmq "github.com/pebbe/zmq4"
-)
+	"time"
 
-var (
-	edpReader *endpointReader
-	conn      *zmq.Socket
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+	"github.com/OnyxPay/OnyxChain-eventbus/remote"
 )
 
-func Start(address string) {
-
-	actor.ProcessRegistry.RegisterAddressResolver(remoteHandler)
-	actor.ProcessRegistry.Address = address
+// Remote is the remoting of an actor system over ZeroMQ
+type Remote struct {
+	*remote.Remote
+}
 
-	spawnActivatorActor()
-	startEndpointManager()
+var defaultRemote *Remote
 
-	edpReader = &endpointReader{}
+// Start the remote server of the default actor system, it also becomes the default remote of the remote package.
+// It returns an error if the address cannot be listened on
+func Start(address string, options ...RemotingOption) error {
+	config := newRemoteConfig(options)
+	if err := remote.Start(address, config.remotingOptions()...); err != nil {
+		return err
+	}
+	defaultRemote = &Remote{remote.DefaultRemote()}
+	return nil
+}
 
-	conn, _ = zmq.NewSocket(zmq.ROUTER)
-	err := conn.Bind("tcp://" + address)
+// StartSystem starts the remote server of an actor system, the PIDs of the system then get the server address.
+// It returns an error if the address cannot be listened on
+func StartSystem(system *actor.ActorSystem, address string, options ...RemotingOption) (*Remote, error) {
+	config := newRemoteConfig(options)
+	r, err := remote.StartSystem(system, address, config.remotingOptions()...)
 	if err != nil {
-		plog.Error("failed to Bind", log.Error(err))
+		return nil, err
 	}
-	plog.Info("Starting Proto.Actor server", log.String("address", address))
-	go func() {
-		edpReader.Receive(conn)
-	}()
+	return &Remote{r}, nil
+}
+
+// defaultShutdownTimeout is how long Shutdonw waits for the queued messages to be sent
+const defaultShutdownTimeout = 10 * time.Second
+
+// Shutdown the remote server of the default actor system, see Remote.Shutdown
+func Shutdown(graceful bool, timeout time.Duration) error {
+	return defaultRemote.Shutdown(graceful, timeout)
 }
 
+// Shutdonw gracefully shuts the remote server of the default actor system down
+//
+// Deprecated: use Shutdown, which reports the undelivered messages
 func Shutdonw() {
-	edpReader.suspend(true)
-	stopEndpointManager()
-	stopActivatorActor()
-	conn.Close()
+	defaultRemote.Shutdown(true, defaultShutdownTimeout)
+}
+
+// Shutdown stops the remote server, the messages then sent to remote PIDs are dead letters.
+// A graceful shutdown stops accepting messages, waits up to timeout for the endpoint writers to send
+// their queued messages and then closes the sockets. It returns a *remote.UndeliveredError counting
+// the undelivered messages by endpoint if some were left
+func (r *Remote) Shutdown(graceful bool, timeout time.Duration) error {
+	return r.Remote.ShutdownTimeout(graceful, timeout)
 }
@@ -0,0 +1,384 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package zmqremote
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/log"
+	"github.com/OnyxPay/OnyxChain-eventbus/remote"
+	zmq "github.com/pebbe/zmq4"
+)
+
+var (
+	// monitorID numbers the inproc addresses the connections are monitored on
+	monitorID uint64
+	// controlID numbers the inproc addresses the receive loops are stopped on
+	controlID uint64
+	// domainID numbers the authentication domains of the transports using CURVE
+	domainID uint64
+	authOnce sync.Once
+	authErr  error
+)
+
+// transport is a remote.Transport over ZeroMQ, a ROUTER socket receives the batches sent by the DEALER sockets of the other nodes.
+// An empty frame is a heartbeat, the ROUTER socket answers it with an empty frame
+type transport struct {
+	dialAddress func(address string) string
+	curve       *curveConfig
+	sendTimeout time.Duration
+	socket      *zmq.Socket
+	receiver    remote.BatchReceiver
+	// control sends the stop request to the receive loop, which owns socket and closes done once it closed it
+	control *zmq.Socket
+	done    chan struct{}
+}
+
+// NewTransport returns a ZeroMQ remote.Transport, configured by the options about the connections
+// such as WithDialAddress and WithCurve
+func NewTransport(options ...RemotingOption) remote.Transport {
+	return newTransport(newRemoteConfig(options))
+}
+
+func newTransport(config *remoteConfig) *transport {
+	return &transport{
+		dialAddress: config.dialAddress,
+		curve:       config.curve,
+		sendTimeout: config.sendTimeout,
+	}
+}
+
+func (t *transport) Listen(address string, receiver remote.BatchReceiver) (string, error) {
+	socket, err := zmq.NewSocket(zmq.ROUTER)
+	if err != nil {
+		return "", err
+	}
+	if t.curve != nil {
+		if err := t.serveCurve(socket); err != nil {
+			socket.Close()
+			return "", err
+		}
+	}
+	if err := socket.Bind("tcp://" + address); err != nil {
+		socket.Close()
+		return "", err
+	}
+	if endpoint, err := socket.GetLastEndpoint(); err == nil {
+		address = strings.TrimPrefix(endpoint, "tcp://")
+	}
+	control, stop, err := newControl()
+	if err != nil {
+		socket.Close()
+		return "", err
+	}
+	t.socket = socket
+	t.receiver = receiver
+	t.control = control
+	t.done = make(chan struct{})
+	go t.receive(stop)
+	return address, nil
+}
+
+// newControl returns a connected pair of sockets, to send the stop request to the receive loop
+func newControl() (control *zmq.Socket, stop *zmq.Socket, err error) {
+	address := fmt.Sprintf("inproc://zmqremote.control.%d", atomic.AddUint64(&controlID, 1))
+	if control, err = zmq.NewSocket(zmq.PAIR); err != nil {
+		return nil, nil, err
+	}
+	if err = control.Bind(address); err != nil {
+		control.Close()
+		return nil, nil, err
+	}
+	if stop, err = zmq.NewSocket(zmq.PAIR); err != nil {
+		control.Close()
+		return nil, nil, err
+	}
+	if err = stop.Connect(address); err != nil {
+		control.Close()
+		stop.Close()
+		return nil, nil, err
+	}
+	return control, stop, nil
+}
+
+// serveCurve makes socket a CURVE server accepting the public keys of the peers only
+func (t *transport) serveCurve(socket *zmq.Socket) error {
+	//the authentication handler is shared by the process, each transport has its own domain
+	authOnce.Do(func() {
+		authErr = zmq.AuthStart()
+		zmq.AuthSetMetadataHandler(curveUserID)
+	})
+	if authErr != nil {
+		return authErr
+	}
+	domain := fmt.Sprintf("zmqremote.%d", atomic.AddUint64(&domainID, 1))
+	keys := make([]string, 0, len(t.curve.peers))
+	for _, key := range t.curve.peers {
+		keys = append(keys, key)
+	}
+	zmq.AuthCurveAdd(domain, keys...)
+	return socket.ServerAuthCurve(domain, t.curve.secretKey)
+}
+
+// curveUserID sets the User-Id of the messages received with CURVE to the public key of their sender
+func curveUserID(version, requestID, domain, address, identity, mechanism string, credentials ...string) map[string]string {
+	if mechanism != "CURVE" || len(credentials) == 0 {
+		return nil
+	}
+	return map[string]string{"User-Id": zmq.Z85encode(credentials[0])}
+}
+
+// receive handles the batches received by the socket until a stop request arrives on stop.
+// ZeroMQ sockets are not thread safe, the loop is the only user of the socket and closes it
+func (t *transport) receive(stop *zmq.Socket) {
+	defer close(t.done)
+	defer stop.Close()
+	defer t.socket.Close()
+
+	poller := zmq.NewPoller()
+	poller.Add(t.socket, zmq.POLLIN)
+	poller.Add(stop, zmq.POLLIN)
+	for {
+		polled, err := poller.Poll(-1)
+		if err != nil {
+			if zmq.AsErrno(err) == zmq.Errno(syscall.EINTR) {
+				continue
+			}
+			plog.Debug("EndpointReader failed to poll", log.Error(err))
+			return
+		}
+		for _, p := range polled {
+			if p.Socket == stop {
+				request, _ := stop.Recv(0)
+				if request == stopGraceful {
+					t.drain()
+				} else {
+					t.socket.SetLinger(0)
+				}
+				return
+			}
+			frames, metadata, err := t.socket.RecvMessageBytesWithMetadata(zmq.DONTWAIT, "User-Id")
+			if err != nil {
+				if zmq.AsErrno(err) == zmq.Errno(syscall.EAGAIN) {
+					continue
+				}
+				plog.Debug("EndpointReader failed to read", log.Error(err))
+				return
+			}
+			t.handle(frames, metadata)
+		}
+	}
+}
+
+// drain handles the batches the socket already received
+func (t *transport) drain() {
+	for {
+		frames, metadata, err := t.socket.RecvMessageBytesWithMetadata(zmq.DONTWAIT, "User-Id")
+		if err != nil {
+			return
+		}
+		t.handle(frames, metadata)
+	}
+}
+
+// handle delivers a batch to the receiver or answers a heartbeat
+func (t *transport) handle(frames [][]byte, metadata map[string]string) {
+	//the ROUTER socket prefixes the batch with the identity of the sender
+	payload := frames[len(frames)-1]
+	if len(payload) == 0 {
+		if len(frames) > 1 && t.receiver.Accepting() {
+			if _, err := t.socket.SendMessage(frames[0], []byte{}); err != nil {
+				plog.Debug("EndpointReader failed to answer heartbeat", log.Error(err))
+			}
+		}
+		return
+	}
+	batch := &remote.MessageBatch{}
+	if err := batch.Unmarshal(payload); err != nil {
+		plog.Debug("EndpointReader failed to deserialize", log.Error(err))
+		return
+	}
+	if err := t.receiver.ReceiveBatch(&remote.Peer{Identity: metadata["User-Id"]}, batch); err != nil {
+		plog.Debug("EndpointReader dropped a batch", log.Error(err))
+	}
+}
+
+func (t *transport) Dial(address string) (remote.Connection, error) {
+	socket, err := zmq.NewSocket(zmq.DEALER)
+	if err != nil {
+		return nil, err
+	}
+	c := &connection{
+		socket: socket,
+		lost:   make(chan struct{}),
+	}
+	//the batches are only queued for a completed connection, a send waits up to the timeout instead of blocking
+	//at the high water mark and then fails with EAGAIN
+	if err := socket.SetImmediate(true); err != nil {
+		socket.Close()
+		return nil, err
+	}
+	if err := socket.SetSndtimeo(t.sendTimeout); err != nil {
+		socket.Close()
+		return nil, err
+	}
+
+	monitor := fmt.Sprintf("inproc://zmqremote.monitor.%d", atomic.AddUint64(&monitorID, 1))
+	//the monitor stops when the socket is closed, which ends the watch
+	if err := socket.Monitor(monitor, zmq.EVENT_DISCONNECTED|zmq.EVENT_MONITOR_STOPPED); err != nil {
+		socket.Close()
+		return nil, err
+	}
+	go c.watch(monitor)
+
+	if t.curve != nil {
+		serverKey, ok := t.curve.peers[address]
+		if !ok {
+			c.Close()
+			return nil, fmt.Errorf("zmqremote: no CURVE key for %v", address)
+		}
+		if err := socket.ClientAuthCurve(serverKey, t.curve.publicKey, t.curve.secretKey); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+	if t.dialAddress != nil {
+		address = t.dialAddress(address)
+	}
+	if err := socket.Connect("tcp://" + address); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+const (
+	stopGraceful = "graceful"
+	stopNow      = "now"
+)
+
+// Stop asks the receive loop to close the socket and waits until it did, after handling the batches already received
+// when graceful is set
+func (t *transport) Stop(graceful bool) {
+	if t.control == nil {
+		return
+	}
+	request := stopNow
+	if graceful {
+		request = stopGraceful
+	}
+	//the loop may have ended on an error already, then it does not take the request
+	if _, err := t.control.Send(request, zmq.DONTWAIT); err != nil {
+		plog.Debug("EndpointReader failed to request stop", log.Error(err))
+	}
+	<-t.done
+	t.control.Close()
+	plog.Info("Stopped Proto.Actor server")
+}
+
+type connection struct {
+	socket   *zmq.Socket
+	lostOnce sync.Once
+	lost     chan struct{}
+}
+
+func (c *connection) SendBatch(batch *remote.MessageBatch) error {
+	data, err := batch.Marshal()
+	if err != nil {
+		return err
+	}
+	_, err = c.socket.SendBytes(data, 0)
+	return err
+}
+
+// Heartbeat counts the answers received since the previous call without waiting, then sends an empty frame without waiting
+func (c *connection) Heartbeat() (int, error) {
+	answered := 0
+	for {
+		if _, err := c.socket.RecvBytes(zmq.DONTWAIT); err != nil {
+			break
+		}
+		answered++
+	}
+	//a heartbeat the socket cannot queue is left unanswered, the failure detector then notices the node
+	if _, err := c.socket.SendBytes([]byte{}, zmq.DONTWAIT); err != nil && zmq.AsErrno(err) != zmq.Errno(syscall.EAGAIN) {
+		return answered, err
+	}
+	return answered, nil
+}
+
+func (c *connection) DefaultSerializerID() int32 {
+	return remote.DefaultSerializerID
+}
+
+func (c *connection) Lost() <-chan struct{} {
+	return c.lost
+}
+
+func (c *connection) Close() error {
+	err := c.socket.Close()
+	c.setLost()
+	return err
+}
+
+// watch marks the connection lost when the DEALER socket disconnects, until the socket is closed
+func (c *connection) watch(monitor string) {
+	pair, err := zmq.NewSocket(zmq.PAIR)
+	if err != nil {
+		return
+	}
+	defer pair.Close()
+	if err := pair.Connect(monitor); err != nil {
+		return
+	}
+	for {
+		event, _, _, err := pair.RecvEvent(0)
+		if err != nil || event == zmq.EVENT_MONITOR_STOPPED {
+			return
+		}
+		if event == zmq.EVENT_DISCONNECTED {
+			c.setLost()
+			return
+		}
+	}
+}
+
+func (c *connection) setLost() {
+	c.lostOnce.Do(func() {
+		close(c.lost)
+	})
+}
@@ -0,0 +1,118 @@
+/****************************************************
+Copyright 2019 The OnyxChain-eventbus Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+
+/***************************************************
+Copyright 2016 https://github.com/AsynkronIT/protoactor-go
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*****************************************************/
+package zmqremote
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/OnyxPay/OnyxChain-eventbus/actor"
+	"github.com/OnyxPay/OnyxChain-eventbus/actor/actortest"
+	"github.com/OnyxPay/OnyxChain-eventbus/remote"
+	zmq "github.com/pebbe/zmq4"
+	"github.com/stretchr/testify/assert"
+)
+
+// freeAddress returns a loopback address with a port free at the time of the call
+func freeAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	return listener.Addr().String()
+}
+
+func newKeypair(t *testing.T) (string, string) {
+	public, secret, err := zmq.NewCurveKeypair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return public, secret
+}
+
+func startSystem(t *testing.T, address string, options ...RemotingOption) (*actor.ActorSystem, *Remote) {
+	system := actor.NewSystem(actor.Config{})
+	r, err := StartSystem(system, address, options...)
+	if err != nil {
+		system.Shutdown()
+		t.Fatal(err)
+	}
+	return system, r
+}
+
+func TestCurve(t *testing.T) {
+	serverAddress, clientAddress, intruderAddress := freeAddress(t), freeAddress(t), freeAddress(t)
+	serverPublic, serverSecret := newKeypair(t)
+	clientPublic, clientSecret := newKeypair(t)
+	intruderPublic, intruderSecret := newKeypair(t)
+
+	var mu sync.Mutex
+	var peers []*remote.Peer
+	authorize := func(peer *remote.Peer, sender *actor.PID, target *actor.PID, typeName string) bool {
+		mu.Lock()
+		peers = append(peers, peer)
+		mu.Unlock()
+		return true
+	}
+	server, serverRemote := startSystem(t, serverAddress,
+		WithCurve(serverPublic, serverSecret, map[string]string{clientAddress: clientPublic}),
+		WithRemotingOptions(remote.WithAuthorizer(authorize)))
+	defer server.Shutdown()
+	defer serverRemote.Shutdown(false, 0)
+	client, clientRemote := startSystem(t, clientAddress,
+		WithCurve(clientPublic, clientSecret, map[string]string{serverAddress: serverPublic}))
+	defer client.Shutdown()
+	defer clientRemote.Shutdown(false, 0)
+	//the intruder knows the key of the server, the server does not know the key of the intruder
+	intruder, intruderRemote := startSystem(t, intruderAddress,
+		WithCurve(intruderPublic, intruderSecret, map[string]string{serverAddress: serverPublic}))
+	defer intruder.Shutdown()
+	defer intruderRemote.Shutdown(false, 0)
+
+	probe := actortest.NewTestProbe(t, server)
+	defer probe.Stop()
+	client.NewPID(serverAddress, probe.PID().Id).Tell(&remote.ActorPidRequest{Name: "client"})
+	probe.ExpectMsg(&remote.ActorPidRequest{Name: "client"}, 5*time.Second)
+
+	//the peer is identified by its CURVE public key
+	mu.Lock()
+	if assert.Len(t, peers, 1) {
+		assert.Equal(t, clientPublic, peers[0].Identity)
+	}
+	mu.Unlock()
+
+	intruder.NewPID(serverAddress, probe.PID().Id).Tell(&remote.ActorPidRequest{Name: "intruder"})
+	probe.ExpectNoMsg(time.Second)
+}